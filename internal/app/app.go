@@ -4,12 +4,17 @@ import (
 	"SkillForge/internal/app/server"
 	"SkillForge/internal/config"
 	"SkillForge/internal/delivery/http"
+	"SkillForge/internal/mail"
 	"SkillForge/internal/service"
+	"SkillForge/internal/service/admin"
+	"SkillForge/internal/service/application"
 	"SkillForge/internal/service/auth"
+	"SkillForge/internal/service/course/cleanup"
 	"SkillForge/internal/service/course/management"
 	"SkillForge/internal/service/course/query"
 	"SkillForge/internal/service/course/rating"
 	"SkillForge/internal/service/course/subscription"
+	"SkillForge/internal/service/lesson/assignment"
 	"SkillForge/internal/service/lesson/content"
 	lm "SkillForge/internal/service/lesson/management"
 	"SkillForge/internal/service/lesson/progress"
@@ -58,24 +63,52 @@ func Run(cfg *config.Config) {
 		log.FatalErr("error creating index", err)
 	}
 
+	var mailSender mail.Sender
+	switch cfg.Mail.Driver {
+	case mail.DriverSMTP:
+		mailSender = mail.NewSMTPSender(cfg.Mail.Host, cfg.Mail.Port, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From)
+	default:
+		mailSender, err = mail.NewLogSender(log, cfg.Mail.From, cfg.Mail.Dir)
+		if err != nil {
+			log.FatalErr("error creating mail sender", err)
+		}
+	}
+
 	tokenRepo := postgres.NewTokensPostgres(pg.Pool)
+	actionTokenRepo := postgres.NewActionTokensPostgres(pg.Pool)
 	courseRepo := postgres.NewCoursePostgres(pg.Pool)
 	userRepo := postgres.NewUserPostgres(pg.Pool)
 	lessonRepo := postgres.NewLessonPostgres(pg.Pool)
 	enrollmentsRepo := postgres.NewSubscriptionPostgres(pg.Pool)
 	ratingRepo := postgres.NewCourseRatingPostgres(pg.Pool)
+	statsRepo := postgres.NewStatsPostgres(pg.Pool)
+	applicationRepo := postgres.NewAuthorApplicationPostgres(pg.Pool)
+	attemptRepo := postgres.NewQuizAttemptPostgres(pg.Pool)
+	submissionRepo := postgres.NewAssignmentPostgres(pg.Pool)
+	cleanupRepo := postgres.NewCleanupPostgres(pg.Pool)
 
 	jwtManager := auth.NewJWTManager(cfg.JWT.SecretKey, "//", cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
-	authService := auth.NewAuthService(log, jwtManager, userRepo, tokenRepo)
+	authService := auth.NewAuthService(log, jwtManager, userRepo, tokenRepo, actionTokenRepo, mailSender, auth.AccountOptions{
+		AppURL:               cfg.Auth.AppURL,
+		RequireVerifiedEmail: cfg.Auth.RequireVerifiedEmail,
+		PasswordResetTTL:     cfg.Auth.PasswordResetTTL,
+		EmailVerifyTTL:       cfg.Auth.EmailVerifyTTL,
+	})
+
+	courseCleaner := cleanup.NewCourseCleaner(log, courseRepo, lessonRepo, courseES, logoStorage, lessonMediaStorage, cleanupRepo)
 
-	courseManagementService := management.NewCourseManagementService(log, userRepo, courseRepo, courseES, logoStorage)
+	courseManagementService := management.NewCourseManagementService(log, userRepo, courseRepo, courseES, logoStorage, courseCleaner)
 	courseRatingService := rating.NewCourseRatingService(log, courseRepo, enrollmentsRepo, ratingRepo)
 	courseSubscriptionService := subscription.NewCourseSubscriptionService(log, courseRepo, enrollmentsRepo)
-	courseQueryService := query.NewCourseQueryService(log, courseRepo, logoStorage, userRepo, courseES, enrollmentsRepo)
+	courseQueryService := query.NewCourseQueryService(log, courseRepo, logoStorage, userRepo, courseES, enrollmentsRepo, lessonRepo)
 
 	lessonManagementService := lm.NewLessonManagementService(log, courseRepo, lessonRepo, lessonMediaStorage)
-	lessonContentService := content.NewLessonContentService(log, lessonRepo, lessonMediaStorage, courseRepo)
-	lessonProgressService := progress.NewLessonProgressService(log, lessonRepo)
+	lessonContentService := content.NewLessonContentService(log, lessonRepo, lessonMediaStorage, courseRepo, enrollmentsRepo)
+	lessonProgressService := progress.NewLessonProgressService(log, lessonRepo, courseRepo, attemptRepo, submissionRepo, enrollmentsRepo)
+	assignmentService := assignment.NewAssignmentService(log, lessonRepo, courseRepo, enrollmentsRepo, submissionRepo, lessonMediaStorage, lessonProgressService)
+
+	adminService := admin.NewAdminService(log, userRepo, tokenRepo, courseRepo, courseCleaner, courseES, statsRepo)
+	applicationService := application.NewAuthorApplicationService(log, applicationRepo, userRepo, mailSender)
 
 	u := service.Collection{
 		AuthService: authService,
@@ -88,6 +121,10 @@ func Run(cfg *config.Config) {
 		LessonContentService:    lessonContentService,
 		LessonProgressService:   lessonProgressService,
 		LessonManagementService: lessonManagementService,
+		AssignmentService:       assignmentService,
+
+		AdminService:             adminService,
+		AuthorApplicationService: applicationService,
 	}
 
 	r := http.InitRoutes(log, u)
@@ -18,3 +18,51 @@ var ErrDuplicateModule = errors.New("module with this order already exists in th
 var ErrAlreadySubscribed = errors.New("user is already subscribed to course")
 var ErrNotRated = errors.New("not rated")
 var ErrAlreadyRated = errors.New("already rated")
+var ErrContentNotFound = errors.New("content not found")
+var ErrInvalidContentPosition = errors.New("invalid content position")
+var ErrInvalidContentOrder = errors.New("content order must list every block of the lesson exactly once")
+var ErrContentTypeMismatch = errors.New("content type cannot be changed")
+var ErrEmptyTextContent = errors.New("text content must not be empty")
+var ErrMediaNotEditable = errors.New("image and video content can only be replaced by uploading a new file")
+var ErrSessionNotFound = errors.New("session not found")
+var ErrTokenReused = errors.New("refresh token reuse detected, session revoked")
+var ErrEmailNotVerified = errors.New("email is not verified")
+var ErrInvalidActionToken = errors.New("token is invalid, expired or already used")
+var ErrUserBlocked = errors.New("user is blocked")
+var ErrRoleNotFound = errors.New("role not found")
+var ErrSelfModeration = errors.New("admins cannot apply this action to their own account")
+var ErrApplicationNotFound = errors.New("author application not found")
+var ErrApplicationPending = errors.New("author application is already pending")
+var ErrApplicationReviewed = errors.New("author application is already reviewed")
+var ErrAlreadyAuthor = errors.New("user is already an author")
+var ErrRejectionReasonRequired = errors.New("rejection reason is required")
+var ErrNothingToUpdate = errors.New("nothing to update")
+var ErrInvalidCourseData = errors.New("course title and description must not be empty")
+var ErrLessonNotFound = errors.New("lesson not found")
+var ErrLessonAccessDenied = errors.New("subscribe to the course to access this lesson")
+var ErrInvalidMinScore = errors.New("min score must be between 0 and 100")
+var ErrQuizNotFound = errors.New("quiz not found in lesson")
+var ErrQuizNotTaken = errors.New("quiz not taken yet")
+var ErrInvalidQuiz = errors.New("invalid quiz")
+var ErrUnknownQuizQuestion = errors.New("unknown quiz question")
+var ErrUnknownQuizOption = errors.New("unknown quiz option")
+var ErrInvalidScorePolicy = errors.New("score policy must be one of best, latest, average")
+var ErrQuizAttemptsExhausted = errors.New("no quiz attempts left")
+var ErrQuizCooldown = errors.New("next quiz attempt is not available yet")
+var ErrQuizNotStarted = errors.New("quiz attempt is not started")
+var ErrQuizTimeExpired = errors.New("quiz time limit expired")
+var ErrQuizStartNotRequired = errors.New("quiz does not need to be started, submit answers directly")
+var ErrQuizAttemptInProgress = errors.New("another quiz attempt is in progress")
+var ErrAssignmentNotFound = errors.New("assignment not found in lesson")
+var ErrInvalidAssignment = errors.New("invalid assignment")
+var ErrInvalidSubmission = errors.New("invalid submission")
+var ErrInvalidGrade = errors.New("invalid grade")
+var ErrSubmissionNotFound = errors.New("submission not found")
+var ErrSubmissionPending = errors.New("previous submission is still waiting for grading")
+var ErrSubmissionGraded = errors.New("submission is already graded")
+var ErrLessonNotStarted = errors.New("lesson not started yet")
+var ErrUnknownImportFormat = errors.New("import format must be one of gift, moodle_xml, qti")
+var ErrInvalidImportFile = errors.New("invalid import file")
+var ErrNothingImported = errors.New("no questions could be imported")
+var ErrLessonGraded = errors.New("lesson with quizzes or assignments is completed by passing them")
+var ErrNotSubscribed = errors.New("subscribe to the course to track progress")
@@ -0,0 +1,26 @@
+package app_errors
+
+import "strings"
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError carries the per-field problems of a rejected document, Err tells what kind of document it was
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Message)
+	}
+	return e.Err.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -13,6 +13,8 @@ type Config struct {
 	HTTPServer HTTPServer `yaml:"http_server"`
 	Postgres   Postgres   `yaml:"postgres"`
 	JWT        JWT        `yaml:"jwt"`
+	Auth       Auth       `yaml:"auth"`
+	Mail       Mail       `yaml:"mail"`
 	ES         ES         `yaml:"elasticsearch"`
 	Minio      Minio      `yaml:"minio"`
 }
@@ -42,6 +44,23 @@ type JWT struct {
 	RefreshTTL time.Duration `yaml:"refresh_token_ttl"`
 }
 
+type Auth struct {
+	AppURL               string        `yaml:"app_url" env-default:"http://localhost:5173"`
+	RequireVerifiedEmail bool          `yaml:"require_verified_email"`
+	PasswordResetTTL     time.Duration `yaml:"password_reset_ttl" env-default:"1h"`
+	EmailVerifyTTL       time.Duration `yaml:"email_verify_ttl" env-default:"24h"`
+}
+
+type Mail struct {
+	Driver   string `yaml:"driver" env-default:"log"` // "smtp" or "log"
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port" env-default:"587"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from" env-default:"no-reply@skillforge.local"`
+	Dir      string `yaml:"dir"`
+}
+
 type Postgres struct {
 	Host     string `yaml:"host"`
 	Port     string `yaml:"port"`
@@ -0,0 +1,255 @@
+package admin
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/delivery/http/controllers/middleware"
+	"SkillForge/internal/models"
+	"SkillForge/pkg/logger"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminService interface {
+	ListUsers(ctx context.Context, search, role string, limit, offset int) ([]models.User, int, error)
+	GrantRole(ctx context.Context, adminID, userID uuid.UUID, role string) error
+	RevokeRole(ctx context.Context, adminID, userID uuid.UUID, role string) error
+	BlockUser(ctx context.Context, adminID, userID uuid.UUID) error
+	UnblockUser(ctx context.Context, adminID, userID uuid.UUID) error
+	ForceHideCourse(ctx context.Context, adminID, courseID uuid.UUID) error
+	ForceDeleteCourse(ctx context.Context, adminID, courseID uuid.UUID) (*models.CourseDeletionReport, error)
+	CleanupFailures(ctx context.Context) ([]models.CleanupFailure, error)
+	RetryCleanup(ctx context.Context, adminID uuid.UUID) (*models.CleanupRetryReport, error)
+	PlatformStats(ctx context.Context) (models.PlatformStats, error)
+}
+
+type AdminHandler struct {
+	log     logger.Log
+	service AdminService
+}
+
+func NewAdminHandler(l logger.Log, s AdminService) *AdminHandler {
+	return &AdminHandler{
+		log:     l,
+		service: s,
+	}
+}
+
+type userResponse struct {
+	UserId        string   `json:"userId"`
+	Username      string   `json:"username"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"emailVerified"`
+	Blocked       bool     `json:"blocked"`
+	Role          []string `json:"role"`
+}
+
+type roleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	limit := 20
+	if s := c.Query("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = v
+	}
+
+	offset := 0
+	if s := c.Query("offset"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		offset = v
+	}
+
+	users, total, err := h.service.ListUsers(c.Request.Context(), c.Query("query"), c.Query("role"), limit, offset)
+	if err != nil {
+		h.log.ErrorErr("ListUsers failed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch users"})
+		return
+	}
+
+	resp := make([]userResponse, 0, len(users))
+	for _, u := range users {
+		resp = append(resp, userResponse{
+			UserId:        u.ID.String(),
+			Username:      u.Username,
+			Email:         u.Email,
+			EmailVerified: u.EmailVerified,
+			Blocked:       u.Blocked,
+			Role:          u.Roles,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"total": total,
+		"users": resp,
+	})
+}
+
+func (h *AdminHandler) GrantRole(c *gin.Context) {
+	adminID, userID, ok := h.moderationTarget(c)
+	if !ok {
+		return
+	}
+	var input roleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.GrantRole(c.Request.Context(), adminID, userID, input.Role); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) RevokeRole(c *gin.Context) {
+	adminID, userID, ok := h.moderationTarget(c)
+	if !ok {
+		return
+	}
+	if err := h.service.RevokeRole(c.Request.Context(), adminID, userID, c.Param("role")); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) BlockUser(c *gin.Context) {
+	adminID, userID, ok := h.moderationTarget(c)
+	if !ok {
+		return
+	}
+	if err := h.service.BlockUser(c.Request.Context(), adminID, userID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) UnblockUser(c *gin.Context) {
+	adminID, userID, ok := h.moderationTarget(c)
+	if !ok {
+		return
+	}
+	if err := h.service.UnblockUser(c.Request.Context(), adminID, userID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) HideCourse(c *gin.Context) {
+	adminID, courseID, ok := h.courseTarget(c)
+	if !ok {
+		return
+	}
+	if err := h.service.ForceHideCourse(c.Request.Context(), adminID, courseID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) DeleteCourse(c *gin.Context) {
+	adminID, courseID, ok := h.courseTarget(c)
+	if !ok {
+		return
+	}
+	report, err := h.service.ForceDeleteCourse(c.Request.Context(), adminID, courseID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *AdminHandler) CleanupFailures(c *gin.Context) {
+	failures, err := h.service.CleanupFailures(c.Request.Context())
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, failures)
+}
+
+func (h *AdminHandler) RetryCleanup(c *gin.Context) {
+	adminID, ok := h.adminID(c)
+	if !ok {
+		return
+	}
+	report, err := h.service.RetryCleanup(c.Request.Context(), adminID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *AdminHandler) Stats(c *gin.Context) {
+	stats, err := h.service.PlatformStats(c.Request.Context())
+	if err != nil {
+		h.log.ErrorErr("PlatformStats failed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not fetch stats"})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *AdminHandler) moderationTarget(c *gin.Context) (adminID, userID uuid.UUID, ok bool) {
+	adminID, ok = h.adminID(c)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+		return adminID, uuid.Nil, false
+	}
+	return adminID, userID, true
+}
+
+func (h *AdminHandler) courseTarget(c *gin.Context) (adminID, courseID uuid.UUID, ok bool) {
+	adminID, ok = h.adminID(c)
+	if !ok {
+		return
+	}
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return adminID, uuid.Nil, false
+	}
+	return adminID, courseID, true
+}
+
+func (h *AdminHandler) adminID(c *gin.Context) (uuid.UUID, bool) {
+	id, ok := c.Get(middleware.ClientIDCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return uuid.Nil, false
+	}
+	return id.(uuid.UUID), true
+}
+
+func (h *AdminHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, app_errors.ErrUserNotFound), errors.Is(err, app_errors.ErrCourseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrRoleNotFound), errors.Is(err, app_errors.ErrSelfModeration):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.log.ErrorErr("admin action failed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
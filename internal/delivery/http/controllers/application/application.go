@@ -0,0 +1,177 @@
+package application
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/delivery/http/controllers/middleware"
+	"SkillForge/internal/models"
+	"SkillForge/pkg/logger"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ApplicationService interface {
+	ApplyForAuthor(ctx context.Context, userID uuid.UUID, motivation string) (*models.AuthorApplication, error)
+	MyApplications(ctx context.Context, userID uuid.UUID) ([]models.AuthorApplication, error)
+	ListApplications(ctx context.Context, status string, limit, offset int) ([]models.AuthorApplication, int, error)
+	ApproveApplication(ctx context.Context, adminID, applicationID uuid.UUID) error
+	RejectApplication(ctx context.Context, adminID, applicationID uuid.UUID, reason string) error
+}
+
+type ApplicationHandler struct {
+	log     logger.Log
+	service ApplicationService
+}
+
+func NewApplicationHandler(l logger.Log, s ApplicationService) *ApplicationHandler {
+	return &ApplicationHandler{
+		log:     l,
+		service: s,
+	}
+}
+
+type applyRequest struct {
+	Motivation string `json:"motivation" binding:"required"`
+}
+
+type rejectRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+func (h *ApplicationHandler) Apply(c *gin.Context) {
+	userID, ok := clientID(c)
+	if !ok {
+		return
+	}
+	var input applyRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	app, err := h.service.ApplyForAuthor(c.Request.Context(), userID, input.Motivation)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, app)
+}
+
+func (h *ApplicationHandler) MyApplications(c *gin.Context) {
+	userID, ok := clientID(c)
+	if !ok {
+		return
+	}
+	apps, err := h.service.MyApplications(c.Request.Context(), userID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, apps)
+}
+
+func (h *ApplicationHandler) ListApplications(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", models.ApplicationPending, models.ApplicationApproved, models.ApplicationRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown application status"})
+		return
+	}
+
+	limit := 20
+	if s := c.Query("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = v
+	}
+
+	offset := 0
+	if s := c.Query("offset"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		offset = v
+	}
+
+	apps, total, err := h.service.ListApplications(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"total":        total,
+		"applications": apps,
+	})
+}
+
+func (h *ApplicationHandler) Approve(c *gin.Context) {
+	adminID, ok := clientID(c)
+	if !ok {
+		return
+	}
+	applicationID, err := uuid.Parse(c.Param("application_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid application_id"})
+		return
+	}
+	if err := h.service.ApproveApplication(c.Request.Context(), adminID, applicationID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ApplicationHandler) Reject(c *gin.Context) {
+	adminID, ok := clientID(c)
+	if !ok {
+		return
+	}
+	applicationID, err := uuid.Parse(c.Param("application_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid application_id"})
+		return
+	}
+	var input rejectRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.RejectApplication(c.Request.Context(), adminID, applicationID, input.Reason); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func clientID(c *gin.Context) (uuid.UUID, bool) {
+	id, ok := c.Get(middleware.ClientIDCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return uuid.Nil, false
+	}
+	return id.(uuid.UUID), true
+}
+
+func (h *ApplicationHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, app_errors.ErrApplicationNotFound), errors.Is(err, app_errors.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrApplicationPending), errors.Is(err, app_errors.ErrApplicationReviewed),
+		errors.Is(err, app_errors.ErrAlreadyAuthor):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrRejectionReasonRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.log.ErrorErr("author application request failed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
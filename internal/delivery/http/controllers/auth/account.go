@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"SkillForge/internal/app_errors"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var input forgotPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AuthService.RequestPasswordReset(c.Request.Context(), input.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.log.ErrorErr("error handling password reset request", err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "if the email is registered, a reset link has been sent"})
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var input resetPasswordRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AuthService.ResetPassword(c.Request.Context(), input.Token, input.Password); err != nil {
+		if errors.Is(err, app_errors.ErrInvalidActionToken) || errors.Is(err, app_errors.ErrIncorrectPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.log.ErrorErr("error handling password reset", err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "password changed"})
+}
+
+type verifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var input verifyEmailRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AuthService.VerifyEmail(c.Request.Context(), input.Token); err != nil {
+		if errors.Is(err, app_errors.ErrInvalidActionToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.log.ErrorErr("error handling email verification", err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "email verified"})
+}
+
+type resendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var input resendVerificationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.AuthService.ResendEmailVerification(c.Request.Context(), input.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.log.ErrorErr("error resending verification email", err, c)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the email is registered and not verified, a verification link has been sent"})
+}
@@ -15,12 +15,19 @@ import (
 
 type AuthService interface {
 	CreateUser(ctx context.Context, user models.User) (*models.User, error)
-	LoginUser(ctx context.Context, username, password string) (accessToken, refreshToken string, err error)
+	LoginUser(ctx context.Context, username, password string, info models.SessionInfo) (accessToken, refreshToken string, err error)
 	ParseToken(ctx context.Context, token string) (*jwt.Token, error)
 	IsAccessToken(ctx context.Context, token *jwt.Token) bool
-	AccessClaims(ctx context.Context, token string) (userID uuid.UUID, roles []string, err error)
+	AccessClaims(ctx context.Context, token string) (userID uuid.UUID, roles []string, sessionID uuid.UUID, err error)
 	User(ctx context.Context, id uuid.UUID) (*models.User, error)
-	RefreshTokens(ctx context.Context, token string) (*models.TokenPair, error)
+	RefreshTokens(ctx context.Context, token string, info models.SessionInfo) (*models.TokenPair, error)
+	Logout(ctx context.Context, token string) error
+	Sessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	ResendEmailVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
 }
 
 type AuthHandler struct {
@@ -36,10 +43,11 @@ func NewAuthHandler(l logger.Log, auth AuthService) *AuthHandler {
 }
 
 type meResponse struct {
-	UserId   string   `json:"userId"`
-	Username string   `json:"username" binding:"required"`
-	Email    string   `json:"email" binding:"required"`
-	Role     []string `json:"role" binding:"required"`
+	UserId        string   `json:"userId"`
+	Username      string   `json:"username" binding:"required"`
+	Email         string   `json:"email" binding:"required"`
+	EmailVerified bool     `json:"emailVerified"`
+	Role          []string `json:"role" binding:"required"`
 }
 
 func (h *AuthHandler) Me(c *gin.Context) {
@@ -61,19 +69,19 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	}
 
 	resp := meResponse{
-		UserId:   userID.String(),
-		Username: user.Username,
-		Email:    user.Email,
-		Role:     user.Roles,
+		UserId:        userID.String(),
+		Username:      user.Username,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Role:          user.Roles,
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
 type registerRequest struct {
-	Username string   `json:"username" binding:"required"`
-	Password string   `json:"password" binding:"required"`
-	Email    string   `json:"email" binding:"required"`
-	Role     []string `json:"role" binding:"required"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Email    string `json:"email" binding:"required"`
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -87,10 +95,6 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Username: input.Username,
 		Password: input.Password,
 		Email:    input.Email,
-		Roles:    make([]string, 0),
-	}
-	for _, role := range input.Role {
-		user.Roles = append(user.Roles, role)
 	}
 
 	_, err := h.AuthService.CreateUser(c.Request.Context(), user)
@@ -109,8 +113,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 }
 
 type loginRequest struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username   string `json:"username" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	DeviceName string `json:"device_name"`
 }
 
 type loginResponse struct {
@@ -125,12 +130,21 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.AuthService.LoginUser(c.Request.Context(), input.Username, input.Password)
+	info := models.SessionInfo{
+		DeviceName: input.DeviceName,
+		UserAgent:  c.Request.UserAgent(),
+		IP:         c.ClientIP(),
+	}
+	accessToken, refreshToken, err := h.AuthService.LoginUser(c.Request.Context(), input.Username, input.Password, info)
 	if err != nil {
 		if errors.Is(err, app_errors.ErrUserNotFound) || errors.Is(err, app_errors.ErrIncorrectPassword) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, app_errors.ErrEmailNotVerified) || errors.Is(err, app_errors.ErrUserBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		h.log.ErrorErr("Error handling login user", err, c)
 		return
@@ -160,12 +174,22 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	tokenPair, err := h.AuthService.RefreshTokens(c.Request.Context(), input.RefreshToken)
+	info := models.SessionInfo{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+	tokenPair, err := h.AuthService.RefreshTokens(c.Request.Context(), input.RefreshToken, info)
 	if err != nil {
-		if errors.Is(err, app_errors.ErrUserNotFound) || errors.Is(err, app_errors.ErrTokenExpired) {
+		if errors.Is(err, app_errors.ErrUserNotFound) || errors.Is(err, app_errors.ErrTokenExpired) ||
+			errors.Is(err, app_errors.ErrTokenNotFound) || errors.Is(err, app_errors.ErrSessionNotFound) ||
+			errors.Is(err, app_errors.ErrTokenReused) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, app_errors.ErrUserBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/delivery/http/controllers/middleware"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var input logoutRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.AuthService.Logout(c.Request.Context(), input.RefreshToken)
+	if err != nil {
+		if errors.Is(err, app_errors.ErrTokenNotFound) || errors.Is(err, app_errors.ErrSessionNotFound) ||
+			errors.Is(err, app_errors.ErrTokenExpired) || errors.Is(err, app_errors.ErrTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.log.ErrorErr("error handling logout", err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "logged out"})
+}
+
+func (h *AuthHandler) Sessions(c *gin.Context) {
+	id, ok := c.Get(middleware.ClientIDCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	userID := id.(uuid.UUID)
+	sessionID, _ := c.Get(middleware.ClientSessionCtx)
+	currentSessionID, _ := sessionID.(uuid.UUID)
+
+	sessions, err := h.AuthService.Sessions(c.Request.Context(), userID, currentSessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.log.ErrorErr("error listing sessions", err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+	id, ok := c.Get(middleware.ClientIDCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	userID := id.(uuid.UUID)
+
+	if err := h.AuthService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, app_errors.ErrSessionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.log.ErrorErr("error revoking session", err, c)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "session revoked"})
+}
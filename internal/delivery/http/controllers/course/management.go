@@ -6,6 +6,7 @@ import (
 	"SkillForge/internal/models"
 	"SkillForge/pkg/logger"
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"io"
@@ -19,8 +20,10 @@ type ManagementService interface {
 	CreateCourse(ctx context.Context, course models.Course) (uuid.UUID, error)
 	Publish(ctx context.Context, id uuid.UUID, authorID uuid.UUID) error
 	Hide(ctx context.Context, id uuid.UUID, authorID uuid.UUID) error
+	UpdateCourse(ctx context.Context, id, authorID uuid.UUID, update models.CourseUpdate) (*models.Course, error)
+	DeleteCourse(ctx context.Context, id, authorID uuid.UUID) (*models.CourseDeletionReport, error)
 	UploadCourseLogo(ctx context.Context, courseID, authorID uuid.UUID, filename string, reader io.Reader, size int64, contentType string) (string, error)
-	GetCourseStatus(ctx context.Context, id uuid.UUID) (string, error)
+	GetCourseStatus(ctx context.Context, id, viewerID uuid.UUID) (string, error)
 }
 
 type ManagementHandler struct {
@@ -65,6 +68,83 @@ func (h *ManagementHandler) CreateCourse(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"id": id})
 }
 
+type updateCourseRequest struct {
+	Title           *string  `json:"title"`
+	Description     *string  `json:"description"`
+	DefaultMinScore *float64 `json:"default_min_score"`
+	ScorePolicy     *string  `json:"score_policy"`
+}
+
+func (h *ManagementHandler) UpdateCourse(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return
+	}
+	var input updateCourseRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ex := c.Get(middleware.ClientIDCtx)
+	if !ex {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	update := models.CourseUpdate{
+		Title:           input.Title,
+		Description:     input.Description,
+		DefaultMinScore: input.DefaultMinScore,
+		ScorePolicy:     input.ScorePolicy,
+	}
+	course, err := h.service.UpdateCourse(c.Request.Context(), courseID, userID.(uuid.UUID), update)
+	if err != nil {
+		switch {
+		case errors.Is(err, app_errors.ErrNotCourseAuthor):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, app_errors.ErrCourseNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, app_errors.ErrNothingToUpdate), errors.Is(err, app_errors.ErrInvalidCourseData),
+			errors.Is(err, app_errors.ErrInvalidMinScore), errors.Is(err, app_errors.ErrInvalidScorePolicy):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.log.ErrorErr("failed to update course", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, course)
+}
+
+func (h *ManagementHandler) DeleteCourse(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return
+	}
+	userID, ex := c.Get(middleware.ClientIDCtx)
+	if !ex {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	report, err := h.service.DeleteCourse(c.Request.Context(), courseID, userID.(uuid.UUID))
+	if err != nil {
+		switch {
+		case errors.Is(err, app_errors.ErrNotCourseAuthor):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, app_errors.ErrCourseNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			h.log.ErrorErr("failed to delete course", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
 func (h *ManagementHandler) PublishCourse(c *gin.Context) {
 	id, ok := c.Params.Get("course_id")
 	if !ok {
@@ -125,8 +205,12 @@ func (h *ManagementHandler) GetCourseStatus(c *gin.Context) {
 		return
 	}
 
-	status, err := h.service.GetCourseStatus(c.Request.Context(), courseID)
+	status, err := h.service.GetCourseStatus(c.Request.Context(), courseID, middleware.ViewerID(c))
 	if err != nil {
+		if errors.Is(err, app_errors.ErrCourseNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -1,10 +1,12 @@
 package course
 
 import (
+	"SkillForge/internal/app_errors"
 	"SkillForge/internal/delivery/http/controllers/middleware"
 	"SkillForge/internal/models"
 	"SkillForge/pkg/logger"
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
@@ -13,7 +15,7 @@ import (
 
 type QueryService interface {
 	GetMyCourses(ctx context.Context, authorID uuid.UUID) ([]models.CoursePreview, error)
-	CourseByID(ctx context.Context, id uuid.UUID) (*models.CoursePreview, error)
+	CourseByID(ctx context.Context, id, viewerID uuid.UUID) (*models.CoursePreview, error)
 	CoursesPreview(ctx context.Context, count int, offset int) ([]models.CoursePreview, int, error)
 	SearchCoursesPreview(ctx context.Context, query string, count int, offset int) ([]models.CoursePreview, int, error)
 	GetSubscribedCourses(ctx context.Context, userID uuid.UUID) ([]models.CoursePreview, error)
@@ -56,8 +58,12 @@ func (h *QueryHandler) CourseByID(c *gin.Context) {
 		return
 	}
 
-	preview, err := h.service.CourseByID(c.Request.Context(), courseID)
+	preview, err := h.service.CourseByID(c.Request.Context(), courseID, middleware.ViewerID(c))
 	if err != nil {
+		if errors.Is(err, app_errors.ErrCourseNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
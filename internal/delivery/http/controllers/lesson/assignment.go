@@ -0,0 +1,216 @@
+package lesson
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/delivery/http/controllers/middleware"
+	"SkillForge/internal/models"
+	"SkillForge/pkg/logger"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+)
+
+type AssignmentService interface {
+	SubmitAssignment(ctx context.Context, lessonID, contentID, userID uuid.UUID, text string, files []models.SubmissionUpload) (*models.AssignmentSubmission, error)
+	MySubmissions(ctx context.Context, lessonID, contentID, userID uuid.UUID) ([]models.AssignmentSubmission, error)
+	GradingQueue(ctx context.Context, courseID, authorID uuid.UUID, status string, limit, offset int) ([]models.AssignmentSubmission, error)
+	GradeSubmission(ctx context.Context, courseID, submissionID, authorID uuid.UUID, scores []models.RubricScore, feedback string) (*models.AssignmentSubmission, error)
+}
+
+type AssignmentHandler struct {
+	log     logger.Log
+	service AssignmentService
+}
+
+func NewAssignmentHandler(log logger.Log, service AssignmentService) *AssignmentHandler {
+	return &AssignmentHandler{
+		log:     log,
+		service: service,
+	}
+}
+
+// Submit takes a multipart form with an optional "text" field and any number of "files"
+func (h *AssignmentHandler) Submit(c *gin.Context) {
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("content_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content_id"})
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	text := c.PostForm("text")
+	var uploads []models.SubmissionUpload
+	if form, err := c.MultipartForm(); err == nil {
+		for _, fileHeader := range form.File["files"] {
+			file, err := fileHeader.Open()
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "cannot open file"})
+				return
+			}
+			defer file.Close()
+
+			ct := fileHeader.Header.Get("Content-Type")
+			if ct == "" {
+				ct = mime.TypeByExtension(filepath.Ext(fileHeader.Filename))
+				if ct == "" {
+					ct = "application/octet-stream"
+				}
+			}
+			uploads = append(uploads, models.SubmissionUpload{
+				Name:        fileHeader.Filename,
+				Reader:      file,
+				Size:        fileHeader.Size,
+				ContentType: ct,
+			})
+		}
+	}
+
+	submission, err := h.service.SubmitAssignment(c.Request.Context(), lessonID, contentID, id.(uuid.UUID), text, uploads)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, submission)
+}
+
+func (h *AssignmentHandler) MySubmissions(c *gin.Context) {
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	contentID, err := uuid.Parse(c.Param("content_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content_id"})
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	submissions, err := h.service.MySubmissions(c.Request.Context(), lessonID, contentID, id.(uuid.UUID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"submissions": submissions})
+}
+
+func (h *AssignmentHandler) GradingQueue(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return
+	}
+	status := c.DefaultQuery("status", models.SubmissionStatusSubmitted)
+	switch status {
+	case models.SubmissionStatusSubmitted, models.SubmissionStatusGraded:
+	case "all":
+		status = ""
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of submitted, graded, all"})
+		return
+	}
+	limit := 20
+	if s := c.Query("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = v
+	}
+	offset := 0
+	if s := c.Query("offset"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		offset = v
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	submissions, err := h.service.GradingQueue(c.Request.Context(), courseID, id.(uuid.UUID), status, limit, offset)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"submissions": submissions, "limit": limit, "offset": offset})
+}
+
+type gradeSubmissionRequest struct {
+	Scores   []models.RubricScore `json:"scores" binding:"required"`
+	Feedback string               `json:"feedback"`
+}
+
+func (h *AssignmentHandler) Grade(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return
+	}
+	submissionID, err := uuid.Parse(c.Param("submission_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid submission_id"})
+		return
+	}
+	var req gradeSubmissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	submission, err := h.service.GradeSubmission(c.Request.Context(), courseID, submissionID, id.(uuid.UUID), req.Scores, req.Feedback)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, submission)
+}
+
+func (h *AssignmentHandler) writeError(c *gin.Context, err error) {
+	var validationErr *app_errors.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Err.Error(), "fields": validationErr.Fields})
+	case errors.Is(err, app_errors.ErrInvalidSubmission):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrNotCourseAuthor), errors.Is(err, app_errors.ErrLessonAccessDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrAssignmentNotFound), errors.Is(err, app_errors.ErrSubmissionNotFound),
+		errors.Is(err, app_errors.ErrContentNotFound), errors.Is(err, app_errors.ErrLessonNotFound),
+		errors.Is(err, app_errors.ErrCourseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrSubmissionPending), errors.Is(err, app_errors.ErrSubmissionGraded):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.log.ErrorErr("assignment request failed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -13,13 +13,19 @@ import (
 	"mime"
 	"net/http"
 	"path/filepath"
+	"strconv"
 )
 
 type ContentService interface {
-	GetLessonDetail(ctx context.Context, lessonID uuid.UUID) (models.LessonDetail, error)
+	GetLessonDetail(ctx context.Context, lessonID, viewerID uuid.UUID, viewerRoles []string) (models.LessonDetail, error)
 	CreateContent(ctx context.Context, content models.CourseContent, authorID uuid.UUID) (*models.CourseContent, error)
-	CreateMediaContent(ctx context.Context, lessonID uuid.UUID, mediaType, filename string, file io.Reader, size int64, contentType string, authorID uuid.UUID) (*models.CourseContent, error)
-	CourseContent(ctx context.Context, courseID uuid.UUID) ([]models.Contents, error)
+	CreateMediaContent(ctx context.Context, lessonID uuid.UUID, mediaType, filename string, file io.Reader, size int64, contentType string, position int, authorID uuid.UUID) (*models.CourseContent, error)
+	CourseContent(ctx context.Context, courseID, viewerID uuid.UUID) ([]models.Contents, error)
+	UpdateContent(ctx context.Context, content models.CourseContent, authorID uuid.UUID) (*models.CourseContent, error)
+	DeleteContent(ctx context.Context, contentID, authorID uuid.UUID) error
+	ReorderContents(ctx context.Context, lessonID uuid.UUID, contentIDs []uuid.UUID, authorID uuid.UUID) error
+	PreviewQuizImport(ctx context.Context, lessonID, authorID uuid.UUID, format, filename, title string, file io.Reader, size int64) (*models.QuizImport, error)
+	ImportQuiz(ctx context.Context, lessonID, authorID uuid.UUID, format, filename, title string, file io.Reader, size int64, position int) (*models.QuizImport, error)
 }
 
 type ContentHandler struct {
@@ -42,8 +48,16 @@ func (h *ContentHandler) GetLessonDetail(c *gin.Context) {
 		return
 	}
 
-	detail, err := h.service.GetLessonDetail(c.Request.Context(), lessonID)
+	detail, err := h.service.GetLessonDetail(c.Request.Context(), lessonID, middleware.ViewerID(c), c.GetStringSlice(middleware.ClientRolesCtx))
 	if err != nil {
+		if errors.Is(err, app_errors.ErrCourseNotFound) || errors.Is(err, app_errors.ErrLessonNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, app_errors.ErrLessonAccessDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -62,6 +76,14 @@ func (h *ContentHandler) CreateMediaContent(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be either 'image' or 'video'"})
 		return
 	}
+	position := 0
+	if p := c.PostForm("position"); p != "" {
+		position, err = strconv.Atoi(p)
+		if err != nil || position < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "position must be a positive integer"})
+			return
+		}
+	}
 
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
@@ -90,13 +112,9 @@ func (h *ContentHandler) CreateMediaContent(c *gin.Context) {
 	}
 	authorID := id.(uuid.UUID)
 
-	content, err := h.service.CreateMediaContent(c.Request.Context(), lessonID, mediaType, fileHeader.Filename, file, fileHeader.Size, ct, authorID)
+	content, err := h.service.CreateMediaContent(c.Request.Context(), lessonID, mediaType, fileHeader.Filename, file, fileHeader.Size, ct, position, authorID)
 	if err != nil {
-		if errors.Is(err, app_errors.ErrNotCourseAuthor) {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.writeContentError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, content)
@@ -114,8 +132,12 @@ func (h *ContentHandler) CourseContent(c *gin.Context) {
 		return
 	}
 
-	content, err := h.service.CourseContent(c.Request.Context(), courseID)
+	content, err := h.service.CourseContent(c.Request.Context(), courseID, middleware.ViewerID(c))
 	if err != nil {
+		if errors.Is(err, app_errors.ErrCourseNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -124,10 +146,12 @@ func (h *ContentHandler) CourseContent(c *gin.Context) {
 }
 
 type createContentRequest struct {
-	LessonID uuid.UUID `json:"lesson_id" binding:"required"`
-	Type     string    `json:"type" binding:"required"` // "text", "image", "video", "quiz"
-	Text     *string   `json:"text,omitempty"`
-	QuizJSON *string   `json:"quiz_json,omitempty"`
+	LessonID       uuid.UUID `json:"lesson_id" binding:"required"`
+	Type           string    `json:"type" binding:"required"` // "text", "image", "video", "quiz", "assignment"
+	Position       int       `json:"position,omitempty" binding:"min=0"`
+	Text           *string   `json:"text,omitempty"`
+	QuizJSON       *string   `json:"quiz_json,omitempty"`
+	AssignmentJSON *string   `json:"assignment_json,omitempty"`
 }
 
 func (h *ContentHandler) CreateContent(c *gin.Context) {
@@ -143,17 +167,199 @@ func (h *ContentHandler) CreateContent(c *gin.Context) {
 	}
 	authorID := id.(uuid.UUID)
 	content := models.CourseContent{
-		LessonID: req.LessonID,
-		Type:     req.Type,
-		Text:     req.Text,
-		QuizJSON: req.QuizJSON,
+		LessonID:       req.LessonID,
+		Type:           req.Type,
+		Order:          req.Position,
+		Text:           req.Text,
+		QuizJSON:       req.QuizJSON,
+		AssignmentJSON: req.AssignmentJSON,
 	}
 
 	createdContent, err := h.service.CreateContent(c.Request.Context(), content, authorID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		h.log.ErrorErr("err", err)
+		h.writeContentError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, createdContent)
 }
+
+type updateContentRequest struct {
+	Type           string  `json:"type,omitempty"`
+	Text           *string `json:"text,omitempty"`
+	QuizJSON       *string `json:"quiz_json,omitempty"`
+	AssignmentJSON *string `json:"assignment_json,omitempty"`
+}
+
+func (h *ContentHandler) UpdateContent(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("content_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content_id"})
+		return
+	}
+	var req updateContentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := c.Get(middleware.ClientIDCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	authorID := id.(uuid.UUID)
+
+	content := models.CourseContent{
+		ID:             contentID,
+		Type:           req.Type,
+		Text:           req.Text,
+		QuizJSON:       req.QuizJSON,
+		AssignmentJSON: req.AssignmentJSON,
+	}
+	updated, err := h.service.UpdateContent(c.Request.Context(), content, authorID)
+	if err != nil {
+		h.writeContentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *ContentHandler) DeleteContent(c *gin.Context) {
+	contentID, err := uuid.Parse(c.Param("content_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content_id"})
+		return
+	}
+	id, ok := c.Get(middleware.ClientIDCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	authorID := id.(uuid.UUID)
+
+	if err := h.service.DeleteContent(c.Request.Context(), contentID, authorID); err != nil {
+		h.writeContentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "content deleted"})
+}
+
+type reorderContentsRequest struct {
+	ContentIDs []uuid.UUID `json:"content_ids" binding:"required"`
+}
+
+func (h *ContentHandler) ReorderContents(c *gin.Context) {
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	var req reorderContentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id, ok := c.Get(middleware.ClientIDCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	authorID := id.(uuid.UUID)
+
+	if err := h.service.ReorderContents(c.Request.Context(), lessonID, req.ContentIDs, authorID); err != nil {
+		h.writeContentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "contents reordered"})
+}
+
+// PreviewQuizImport takes the same multipart form as ImportQuiz and returns the converted quiz without saving it
+func (h *ContentHandler) PreviewQuizImport(c *gin.Context) {
+	h.importQuiz(c, true)
+}
+
+// ImportQuiz takes a multipart form with the "file" to import, the "lesson_id" and optional "format"
+// (gift, moodle_xml or qti, guessed from the file when empty), "title" and "position"
+func (h *ContentHandler) ImportQuiz(c *gin.Context) {
+	h.importQuiz(c, false)
+}
+
+func (h *ContentHandler) importQuiz(c *gin.Context, preview bool) {
+	lessonID, err := uuid.Parse(c.PostForm("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	format := c.PostForm("format")
+	switch format {
+	case "", models.ImportFormatGIFT, models.ImportFormatMoodleXML, models.ImportFormatQTI:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": app_errors.ErrUnknownImportFormat.Error()})
+		return
+	}
+	position := 0
+	if p := c.PostForm("position"); p != "" {
+		position, err = strconv.Atoi(p)
+		if err != nil || position < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "position must be a positive integer"})
+			return
+		}
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "cannot open file"})
+		return
+	}
+	defer file.Close()
+
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	authorID := id.(uuid.UUID)
+
+	if preview {
+		result, err := h.service.PreviewQuizImport(c.Request.Context(), lessonID, authorID, format, fileHeader.Filename, c.PostForm("title"), file, fileHeader.Size)
+		if err != nil {
+			h.writeContentError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	result, err := h.service.ImportQuiz(c.Request.Context(), lessonID, authorID, format, fileHeader.Filename, c.PostForm("title"), file, fileHeader.Size, position)
+	if err != nil {
+		h.writeContentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *ContentHandler) writeContentError(c *gin.Context, err error) {
+	var validationErr *app_errors.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Err.Error(), "fields": validationErr.Fields})
+	case errors.Is(err, app_errors.ErrNotCourseAuthor):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrContentNotFound), errors.Is(err, app_errors.ErrCourseNotFound),
+		errors.Is(err, app_errors.ErrLessonNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrInvalidContentPosition), errors.Is(err, app_errors.ErrInvalidContentOrder),
+		errors.Is(err, app_errors.ErrContentTypeMismatch), errors.Is(err, app_errors.ErrUnknownImportFormat),
+		errors.Is(err, app_errors.ErrInvalidImportFile), errors.Is(err, app_errors.ErrNothingImported),
+		errors.Is(err, app_errors.ErrEmptyTextContent), errors.Is(err, app_errors.ErrMediaNotEditable):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrFileSize):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	default:
+		h.log.ErrorErr("content request failed", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -19,6 +19,7 @@ type ManagementService interface {
 	DeleteModule(ctx context.Context, courseID, moduleID uuid.UUID, authorID uuid.UUID) error
 	SwapLessons(ctx context.Context, lessonID1, lessonID2, authorID uuid.UUID) error
 	SwapModules(ctx context.Context, moduleID1, moduleID2, courseID, authorID uuid.UUID) error
+	SetFreePreview(ctx context.Context, courseID, lessonID, authorID uuid.UUID, freePreview bool) error
 }
 
 type ManagementHandler struct {
@@ -118,6 +119,7 @@ func (h *ManagementHandler) DeleteModule(c *gin.Context) {
 type createLessonRequest struct {
 	ModuleID    uuid.UUID `json:"module_id" binding:"required"`
 	LessonTitle string    `json:"lesson_title" binding:"required"`
+	FreePreview bool      `json:"free_preview"`
 }
 
 func (h *ManagementHandler) CreateLesson(c *gin.Context) {
@@ -149,6 +151,7 @@ func (h *ManagementHandler) CreateLesson(c *gin.Context) {
 		CourseID:    courseID,
 		ModuleID:    input.ModuleID,
 		LessonTitle: input.LessonTitle,
+		FreePreview: input.FreePreview,
 	}
 	createdLesson, err := h.service.CreateLesson(c.Request.Context(), lesson, authorID)
 	if err != nil {
@@ -286,3 +289,45 @@ func (h *ManagementHandler) SwapModules(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "modules swapped"})
 }
+
+type freePreviewRequest struct {
+	FreePreview *bool `json:"free_preview" binding:"required"`
+}
+
+func (h *ManagementHandler) SetFreePreview(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return
+	}
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	var req freePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, ok := c.Get(middleware.ClientIDCtx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	authorID := id.(uuid.UUID)
+
+	if err := h.service.SetFreePreview(c.Request.Context(), courseID, lessonID, authorID, *req.FreePreview); err != nil {
+		switch {
+		case errors.Is(err, app_errors.ErrNotCourseAuthor):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, app_errors.ErrLessonNotFound), errors.Is(err, app_errors.ErrCourseNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"free_preview": *req.FreePreview})
+}
@@ -1,18 +1,28 @@
 package lesson
 
 import (
+	"SkillForge/internal/app_errors"
 	"SkillForge/internal/delivery/http/controllers/middleware"
 	"SkillForge/internal/models"
 	"SkillForge/pkg/logger"
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
+	"strconv"
 )
 
 type ProgressService interface {
-	SubmitQuizAnswers(ctx context.Context, lessonID uuid.UUID, userID uuid.UUID, answers []models.QuizAnswer) (float64, error)
-	GetQuizResult(ctx context.Context, lessonID, userID uuid.UUID) (float64, string, error)
+	SubmitQuizAnswers(ctx context.Context, lessonID, contentID, userID uuid.UUID, answers []models.QuizAnswer) (*models.QuizResult, error)
+	GetQuizResult(ctx context.Context, lessonID, contentID, userID uuid.UUID) (*models.QuizResult, error)
+	GetLessonProgress(ctx context.Context, lessonID, userID uuid.UUID) (models.LessonProgress, error)
+	StartQuiz(ctx context.Context, lessonID, contentID, userID uuid.UUID) (*models.StartedQuiz, error)
+	MyAttempts(ctx context.Context, lessonID, contentID, userID uuid.UUID) ([]models.QuizAttempt, error)
+	LessonAttempts(ctx context.Context, courseID, lessonID, contentID, authorID, learnerID uuid.UUID, limit, offset int) ([]models.QuizAttempt, error)
+	QuizAnalytics(ctx context.Context, courseID, lessonID, contentID, authorID uuid.UUID) (*models.QuizAnalytics, error)
+	CompleteLesson(ctx context.Context, lessonID, userID uuid.UUID) (models.LessonProgress, error)
+	CourseProgress(ctx context.Context, courseID, userID uuid.UUID) (*models.CourseProgress, error)
 }
 
 type ProgressHandler struct {
@@ -36,6 +46,10 @@ func (h *ProgressHandler) SubmitQuiz(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
 		return
 	}
+	contentID, ok := quizContentID(c)
+	if !ok {
+		return
+	}
 
 	var req submitQuizRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -50,15 +64,37 @@ func (h *ProgressHandler) SubmitQuiz(c *gin.Context) {
 	}
 	userID := id.(uuid.UUID)
 
-	score, err := h.service.SubmitQuizAnswers(c.Request.Context(), lessonID, userID, req.Answers)
+	result, err := h.service.SubmitQuizAnswers(c.Request.Context(), lessonID, contentID, userID, req.Answers)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"score": score,
-	})
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ProgressHandler) StartQuiz(c *gin.Context) {
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	contentID, ok := quizContentID(c)
+	if !ok {
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	started, err := h.service.StartQuiz(c.Request.Context(), lessonID, contentID, id.(uuid.UUID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, started)
 }
 
 func (h *ProgressHandler) GetQuizResult(c *gin.Context) {
@@ -68,6 +104,10 @@ func (h *ProgressHandler) GetQuizResult(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
 		return
 	}
+	contentID, ok := quizContentID(c)
+	if !ok {
+		return
+	}
 
 	id, exists := c.Get(middleware.ClientIDCtx)
 	if !exists {
@@ -76,14 +116,216 @@ func (h *ProgressHandler) GetQuizResult(c *gin.Context) {
 	}
 	userID := id.(uuid.UUID)
 
-	score, status, err := h.service.GetQuizResult(c.Request.Context(), lessonID, userID)
+	result, err := h.service.GetQuizResult(c.Request.Context(), lessonID, contentID, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.writeError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"score":  score,
-		"status": status,
-	})
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ProgressHandler) MyAttempts(c *gin.Context) {
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	contentID, ok := quizContentID(c)
+	if !ok {
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	attempts, err := h.service.MyAttempts(c.Request.Context(), lessonID, contentID, id.(uuid.UUID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"attempts": attempts})
+}
+
+func (h *ProgressHandler) LessonAttempts(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return
+	}
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	contentID, ok := quizContentID(c)
+	if !ok {
+		return
+	}
+	var learnerID uuid.UUID
+	if s := c.Query("user_id"); s != "" {
+		learnerID, err = uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+			return
+		}
+	}
+	limit := 20
+	if s := c.Query("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = v
+	}
+	offset := 0
+	if s := c.Query("offset"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		offset = v
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	attempts, err := h.service.LessonAttempts(c.Request.Context(), courseID, lessonID, contentID, id.(uuid.UUID), learnerID, limit, offset)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"attempts": attempts, "limit": limit, "offset": offset})
+}
+
+func (h *ProgressHandler) QuizAnalytics(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return
+	}
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	contentID, ok := quizContentID(c)
+	if !ok {
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	analytics, err := h.service.QuizAnalytics(c.Request.Context(), courseID, lessonID, contentID, id.(uuid.UUID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, analytics)
+}
+
+func (h *ProgressHandler) GetLessonProgress(c *gin.Context) {
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	progress, err := h.service.GetLessonProgress(c.Request.Context(), lessonID, id.(uuid.UUID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
+
+// quizContentID reads the quiz block from the path; routes without content_id address the first quiz of the lesson
+func quizContentID(c *gin.Context) (uuid.UUID, bool) {
+	s := c.Param("content_id")
+	if s == "" {
+		return uuid.Nil, true
+	}
+	contentID, err := uuid.Parse(s)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid content_id"})
+		return uuid.Nil, false
+	}
+	return contentID, true
+}
+
+func (h *ProgressHandler) CompleteLesson(c *gin.Context) {
+	lessonID, err := uuid.Parse(c.Param("lesson_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lesson_id"})
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	progress, err := h.service.CompleteLesson(c.Request.Context(), lessonID, id.(uuid.UUID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
+
+func (h *ProgressHandler) CourseProgress(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Param("course_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course_id"})
+		return
+	}
+	id, exists := c.Get(middleware.ClientIDCtx)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	progress, err := h.service.CourseProgress(c.Request.Context(), courseID, id.(uuid.UUID))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
+
+func (h *ProgressHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, app_errors.ErrNotCourseAuthor), errors.Is(err, app_errors.ErrNotSubscribed):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrQuizNotFound), errors.Is(err, app_errors.ErrQuizNotTaken),
+		errors.Is(err, app_errors.ErrLessonNotStarted), errors.Is(err, app_errors.ErrLessonNotFound),
+		errors.Is(err, app_errors.ErrCourseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrUnknownQuizQuestion), errors.Is(err, app_errors.ErrUnknownQuizOption):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrQuizAttemptsExhausted):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrQuizCooldown):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, app_errors.ErrQuizNotStarted), errors.Is(err, app_errors.ErrQuizAttemptInProgress),
+		errors.Is(err, app_errors.ErrQuizTimeExpired), errors.Is(err, app_errors.ErrQuizStartNotRequired),
+		errors.Is(err, app_errors.ErrLessonGraded):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
@@ -16,8 +16,9 @@ import (
 type AuthService interface {
 	ParseToken(ctx context.Context, token string) (*jwt.Token, error)
 	IsAccessToken(ctx context.Context, token *jwt.Token) bool
-	AccessClaims(ctx context.Context, token string) (userID uuid.UUID, roles []string, err error)
+	AccessClaims(ctx context.Context, token string) (userID uuid.UUID, roles []string, sessionID uuid.UUID, err error)
 	User(ctx context.Context, id uuid.UUID) (*models.User, error)
+	TouchSession(ctx context.Context, userID, sessionID uuid.UUID) error
 }
 
 type AuthMiddlewareProvider struct {
@@ -31,47 +32,89 @@ func NewAuthMiddlewareProvider(log logger.Log, s AuthService) *AuthMiddlewarePro
 		service: s,
 	}
 }
+
 func (h *AuthMiddlewareProvider) AuthMiddleware(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	var token string
-	if parts := strings.Split(authHeader, "Bearer "); len(parts) == 2 {
-		token = parts[1]
-	}
+	token := bearerToken(c)
 	if token == "" {
 		c.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
+	if h.authenticate(c, token) {
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware lets anonymous requests through, but a request that carries a token
+// must carry a valid one
+func (h *AuthMiddlewareProvider) OptionalAuthMiddleware(c *gin.Context) {
+	token := bearerToken(c)
+	if token == "" {
+		c.Next()
+		return
+	}
+	if h.authenticate(c, token) {
+		c.Next()
+	}
+}
+
+func bearerToken(c *gin.Context) string {
+	if parts := strings.Split(c.GetHeader("Authorization"), "Bearer "); len(parts) == 2 {
+		return parts[1]
+	}
+	return ""
+}
 
+func (h *AuthMiddlewareProvider) authenticate(c *gin.Context, token string) bool {
 	parsedToken, err := h.service.ParseToken(c.Request.Context(), token)
 	if err != nil {
 		h.log.Info("failed to parse token", err)
 		if errors.Is(err, app_errors.ErrTokenExpired) {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": app_errors.ErrTokenExpired.Error()})
-			return
+			return false
 		}
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "cant parse token"})
-		return
+		return false
 	}
 	if !h.service.IsAccessToken(c.Request.Context(), parsedToken) {
 		//h.log.Error("not access")\
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "not access token"})
 
-		return
+		return false
 	}
 
-	userID, roles, err := h.service.AccessClaims(c.Request.Context(), token)
+	userID, _, sessionID, err := h.service.AccessClaims(c.Request.Context(), token)
 	if err != nil {
 		//h.log.Error("claims")
 		c.AbortWithStatus(http.StatusUnauthorized)
-		return
+		return false
 	}
 	user, err := h.service.User(c.Request.Context(), userID)
 	if err != nil {
 		c.AbortWithStatus(http.StatusUnauthorized)
-		return
+		return false
+	}
+	if user.Blocked {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": app_errors.ErrUserBlocked.Error()})
+		return false
+	}
+
+	// tokens issued before sessions were tracked carry no sid and could not be revoked, so they are refused
+	if sessionID == uuid.Nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": app_errors.ErrSessionNotFound.Error()})
+		return false
+	}
+	if err := h.service.TouchSession(c.Request.Context(), user.ID, sessionID); err != nil {
+		if errors.Is(err, app_errors.ErrSessionNotFound) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return false
+		}
+		h.log.ErrorErr("failed to check session", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return false
 	}
 
 	c.Set(ClientIDCtx, user.ID)
-	c.Set(ClientRolesCtx, roles)
-	c.Next()
+	c.Set(ClientRolesCtx, user.Roles)
+	c.Set(ClientSessionCtx, sessionID)
+	return true
 }
@@ -2,9 +2,21 @@ package middleware
 
 import (
 	_ "encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 const (
-	ClientIDCtx    = "client_id"
-	ClientRolesCtx = "client_roles"
+	ClientIDCtx      = "client_id"
+	ClientRolesCtx   = "client_roles"
+	ClientSessionCtx = "client_session"
 )
+
+// ViewerID returns the authenticated user or uuid.Nil for anonymous requests
+func ViewerID(c *gin.Context) uuid.UUID {
+	if id, ok := c.Get(ClientIDCtx); ok {
+		return id.(uuid.UUID)
+	}
+	return uuid.Nil
+}
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"SkillForge/internal/delivery/http/controllers/admin"
+	"SkillForge/internal/delivery/http/controllers/application"
 	"SkillForge/internal/delivery/http/controllers/auth"
 	"SkillForge/internal/delivery/http/controllers/course"
 	"SkillForge/internal/delivery/http/controllers/lesson"
@@ -43,6 +45,10 @@ func InitRoutes(l logger.Log, u service.Collection) *gin.Engine {
 	lessonManagementHandler := lesson.NewManagementHandler(l, u.LessonManagementService)
 	lessonProgressHandler := lesson.NewProgressHandler(l, u.LessonProgressService)
 	lessonContentHandler := lesson.NewContentHandler(l, u.LessonContentService)
+	assignmentHandler := lesson.NewAssignmentHandler(l, u.AssignmentService)
+
+	adminHandler := admin.NewAdminHandler(l, u.AdminService)
+	applicationHandler := application.NewApplicationHandler(l, u.AuthorApplicationService)
 
 	v1 := r.Group("/v1", middleware.LoggingMiddleware(l))
 	{
@@ -55,19 +61,35 @@ func InitRoutes(l logger.Log, u service.Collection) *gin.Engine {
 			auth.POST("/login", authHandler.Login)
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/logout", authHandler.Logout)
+			auth.GET("/sessions", authMiddlewareProvider.AuthMiddleware, authHandler.Sessions)
+			auth.DELETE("/sessions/:id", authMiddlewareProvider.AuthMiddleware, authHandler.RevokeSession)
+			auth.POST("/password/forgot", authHandler.ForgotPassword)
+			auth.POST("/password/reset", authHandler.ResetPassword)
+			auth.POST("/email/verify", authHandler.VerifyEmail)
+			auth.POST("/email/resend", authHandler.ResendVerification)
+		}
+
+		applications := v1.Group("/author-applications", authMiddlewareProvider.AuthMiddleware)
+		{
+			applications.POST("", applicationHandler.Apply)
+			applications.GET("/my", applicationHandler.MyApplications)
 		}
 
 		courses := v1.Group("/courses")
 		{
 			courses.GET("", courseQueryHandler.ListCoursePreview)
-			courses.GET("/:course_id/preview", courseQueryHandler.CourseByID)
-			courses.GET("/:course_id/content", lessonContentHandler.CourseContent)
-			courses.GET("/:course_id/status", courseManagementHandler.GetCourseStatus)
+			courses.GET("/:course_id/preview", authMiddlewareProvider.OptionalAuthMiddleware, courseQueryHandler.CourseByID)
+			courses.GET("/:course_id/content", authMiddlewareProvider.OptionalAuthMiddleware, lessonContentHandler.CourseContent)
+			courses.GET("/:course_id/status", authMiddlewareProvider.OptionalAuthMiddleware, courseManagementHandler.GetCourseStatus)
+			courses.GET("/lessons/:lesson_id/preview", authMiddlewareProvider.OptionalAuthMiddleware, lessonContentHandler.GetLessonDetail)
 
 			author := courses.Group("", authMiddlewareProvider.AuthMiddleware, middleware.RequireRoles(models.AuthorRole))
 			{
 				author.PUT("/:course_id/logo", courseManagementHandler.UploadCourseLogo)
 				author.POST("", courseManagementHandler.CreateCourse)
+				author.PATCH("/:course_id", courseManagementHandler.UpdateCourse)
+				author.DELETE("/:course_id", courseManagementHandler.DeleteCourse)
 				author.PATCH("/:course_id/publish", courseManagementHandler.PublishCourse)
 				author.PATCH("/:course_id/hide", courseManagementHandler.HideCourse)
 				author.POST("/:course_id/create-lesson", lessonManagementHandler.CreateLesson)
@@ -79,7 +101,18 @@ func InitRoutes(l logger.Log, u service.Collection) *gin.Engine {
 				author.PATCH("/:course_id/modules/swap", lessonManagementHandler.SwapModules)
 				author.POST("/:course_id/lesson/content", lessonContentHandler.CreateContent)
 				author.POST("/:course_id/lesson/content/media", lessonContentHandler.CreateMediaContent)
+				author.POST("/:course_id/lesson/content/import", lessonContentHandler.ImportQuiz)
+				author.POST("/:course_id/lesson/content/import/preview", lessonContentHandler.PreviewQuizImport)
+				author.PATCH("/:course_id/lesson/content/:content_id", lessonContentHandler.UpdateContent)
+				author.DELETE("/:course_id/lesson/content/:content_id", lessonContentHandler.DeleteContent)
+				author.PATCH("/:course_id/lessons/:lesson_id/contents/order", lessonContentHandler.ReorderContents)
 				author.GET("/:course_id/lessons/:lesson_id", lessonContentHandler.GetLessonDetail)
+				author.PATCH("/:course_id/lessons/:lesson_id/free-preview", lessonManagementHandler.SetFreePreview)
+				author.GET("/:course_id/lessons/:lesson_id/quiz/attempts", lessonProgressHandler.LessonAttempts)
+				author.GET("/:course_id/lessons/:lesson_id/quizzes/:content_id/attempts", lessonProgressHandler.LessonAttempts)
+				author.GET("/:course_id/lessons/:lesson_id/quizzes/:content_id/analytics", lessonProgressHandler.QuizAnalytics)
+				author.GET("/:course_id/assignments/submissions", assignmentHandler.GradingQueue)
+				author.PATCH("/:course_id/assignments/submissions/:submission_id/grade", assignmentHandler.Grade)
 			}
 
 			client := courses.Group("", authMiddlewareProvider.AuthMiddleware, middleware.RequireRoles(models.ClientRole))
@@ -87,8 +120,19 @@ func InitRoutes(l logger.Log, u service.Collection) *gin.Engine {
 				client.POST("/:course_id/subscribe", courseSubscriptionHandler.SubscribeCourse)
 				client.GET("/subscriptions", courseQueryHandler.GetSubscribedCourses)
 				client.GET("/lessons/:lesson_id", lessonContentHandler.GetLessonDetail)
+				client.POST("/lessons/:lesson_id/quiz/start", lessonProgressHandler.StartQuiz)
 				client.POST("/lessons/:lesson_id/quiz/submit", lessonProgressHandler.SubmitQuiz)
 				client.GET("/lessons/:lesson_id/quiz/result", lessonProgressHandler.GetQuizResult)
+				client.GET("/lessons/:lesson_id/quiz/attempts", lessonProgressHandler.MyAttempts)
+				client.POST("/lessons/:lesson_id/quizzes/:content_id/start", lessonProgressHandler.StartQuiz)
+				client.POST("/lessons/:lesson_id/quizzes/:content_id/submit", lessonProgressHandler.SubmitQuiz)
+				client.GET("/lessons/:lesson_id/quizzes/:content_id/result", lessonProgressHandler.GetQuizResult)
+				client.GET("/lessons/:lesson_id/quizzes/:content_id/attempts", lessonProgressHandler.MyAttempts)
+				client.GET("/lessons/:lesson_id/progress", lessonProgressHandler.GetLessonProgress)
+				client.POST("/lessons/:lesson_id/complete", lessonProgressHandler.CompleteLesson)
+				client.GET("/:course_id/progress", lessonProgressHandler.CourseProgress)
+				client.POST("/lessons/:lesson_id/assignments/:content_id/submissions", assignmentHandler.Submit)
+				client.GET("/lessons/:lesson_id/assignments/:content_id/submissions", assignmentHandler.MySubmissions)
 				client.POST("/:course_id/star", courseRatingHandler.RateCourse)
 				client.DELETE("/:course_id/star", courseRatingHandler.UnrateCourse)
 				client.GET("/rated-status", courseRatingHandler.GetRatingStatus)
@@ -96,6 +140,22 @@ func InitRoutes(l logger.Log, u service.Collection) *gin.Engine {
 
 		}
 
+		admin := v1.Group("/admin", authMiddlewareProvider.AuthMiddleware, middleware.RequireRoles(models.AdminRole))
+		{
+			admin.GET("/users", adminHandler.ListUsers)
+			admin.POST("/users/:user_id/roles", adminHandler.GrantRole)
+			admin.DELETE("/users/:user_id/roles/:role", adminHandler.RevokeRole)
+			admin.PATCH("/users/:user_id/block", adminHandler.BlockUser)
+			admin.PATCH("/users/:user_id/unblock", adminHandler.UnblockUser)
+			admin.PATCH("/courses/:course_id/hide", adminHandler.HideCourse)
+			admin.DELETE("/courses/:course_id", adminHandler.DeleteCourse)
+			admin.GET("/cleanup-failures", adminHandler.CleanupFailures)
+			admin.POST("/cleanup-failures/retry", adminHandler.RetryCleanup)
+			admin.GET("/stats", adminHandler.Stats)
+			admin.GET("/author-applications", applicationHandler.ListApplications)
+			admin.PATCH("/author-applications/:application_id/approve", applicationHandler.Approve)
+			admin.PATCH("/author-applications/:application_id/reject", applicationHandler.Reject)
+		}
 	}
 	return r
 }
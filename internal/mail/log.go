@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"SkillForge/internal/models"
+	"SkillForge/pkg/logger"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogSender is meant for local development: messages are written to the log
+// and, when dir is set, saved there as .eml files instead of being delivered.
+type LogSender struct {
+	log  logger.Log
+	from string
+	dir  string
+}
+
+func NewLogSender(log logger.Log, from, dir string) (*LogSender, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create mail dir: %w", err)
+		}
+	}
+	return &LogSender{log: log, from: from, dir: dir}, nil
+}
+
+func (s *LogSender) Send(ctx context.Context, msg models.MailMessage) error {
+	s.log.Info("mail sent", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	if s.dir == "" {
+		return nil
+	}
+	name := fmt.Sprintf("%s_%s.eml", time.Now().UTC().Format("20060102T150405"), uuid.NewString())
+	if err := os.WriteFile(filepath.Join(s.dir, name), buildMessage(s.from, msg), 0o644); err != nil {
+		return fmt.Errorf("write mail file: %w", err)
+	}
+	return nil
+}
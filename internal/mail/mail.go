@@ -0,0 +1,15 @@
+package mail
+
+import (
+	"SkillForge/internal/models"
+	"context"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+)
+
+type Sender interface {
+	Send(ctx context.Context, msg models.MailMessage) error
+}
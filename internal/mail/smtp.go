@@ -0,0 +1,52 @@
+package mail
+
+import (
+	"SkillForge/internal/models"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPSender(host, port, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg models.MailMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{msg.To}, buildMessage(s.from, msg)); err != nil {
+		return fmt.Errorf("smtp send to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+func buildMessage(from string, msg models.MailMessage) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SubmissionStatusSubmitted = "submitted"
+	SubmissionStatusGraded    = "graded"
+
+	DefaultAssignmentMaxFiles = 5
+)
+
+// Assignment is open-ended work graded by the course author against the rubric
+type Assignment struct {
+	Title        string            `json:"title"`
+	Instructions string            `json:"instructions"`
+	AllowText    bool              `json:"allowText"`
+	AllowFiles   bool              `json:"allowFiles"`
+	MaxFiles     int               `json:"maxFiles,omitempty"`
+	MinScore     *float64          `json:"minScore,omitempty"`
+	Rubric       []RubricCriterion `json:"rubric"`
+}
+
+type RubricCriterion struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	MaxPoints   float64 `json:"maxPoints"`
+}
+
+func (a Assignment) MaxPoints() float64 {
+	var total float64
+	for _, criterion := range a.Rubric {
+		total += criterion.MaxPoints
+	}
+	return total
+}
+
+type AssignmentSubmission struct {
+	ID          uuid.UUID        `json:"id"`
+	ContentID   uuid.UUID        `json:"content_id"`
+	LessonID    uuid.UUID        `json:"lesson_id"`
+	UserID      uuid.UUID        `json:"user_id"`
+	Username    string           `json:"username,omitempty"`
+	Text        string           `json:"text"`
+	Files       []SubmissionFile `json:"files"`
+	Status      string           `json:"status"`
+	Scores      []RubricScore    `json:"scores"`
+	Points      float64          `json:"points"`
+	MaxPoints   float64          `json:"max_points"`
+	Score       float64          `json:"score"`
+	MinScore    float64          `json:"min_score"`
+	Result      string           `json:"result,omitempty"`
+	Feedback    string           `json:"feedback,omitempty"`
+	GradedBy    *uuid.UUID       `json:"graded_by,omitempty"`
+	SubmittedAt time.Time        `json:"submitted_at"`
+	GradedAt    *time.Time       `json:"graded_at,omitempty"`
+}
+
+// SubmissionFile is stored with its object key; URL is a presigned link filled in for responses
+type SubmissionFile struct {
+	Name        string `json:"name"`
+	ObjectKey   string `json:"object_key"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+	URL         string `json:"url,omitempty"`
+}
+
+type RubricScore struct {
+	CriterionID string  `json:"criterion_id"`
+	Points      float64 `json:"points"`
+	Comment     string  `json:"comment,omitempty"`
+}
+
+// SubmissionUpload is a file of a learner's submission on its way to storage
+type SubmissionUpload struct {
+	Name        string
+	Reader      io.Reader
+	Size        int64
+	ContentType string
+}
@@ -9,18 +9,32 @@ import (
 const (
 	StatusHidden = "hidden"
 	StatusPublic = "public"
+
+	ScorePolicyBest    = "best"
+	ScorePolicyLatest  = "latest"
+	ScorePolicyAverage = "average"
 )
 
 type Course struct {
-	ID            uuid.UUID `json:"id"`
-	Title         string    `json:"title"`
-	Description   string    `json:"description"`
-	LogoObjectKey string    `json:"logo_object_key"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	AuthorID      uuid.UUID `json:"author_id"`
-	Status        string    `json:"status"`
-	StarsCount    int       `json:"stars_count"`
+	ID              uuid.UUID `json:"id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	LogoObjectKey   string    `json:"logo_object_key"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	AuthorID        uuid.UUID `json:"author_id"`
+	Status          string    `json:"status"`
+	StarsCount      int       `json:"stars_count"`
+	DefaultMinScore float64   `json:"default_min_score"`
+	ScorePolicy     string    `json:"score_policy"`
+}
+
+// CourseUpdate holds the editable course fields, nil means leave unchanged
+type CourseUpdate struct {
+	Title           *string
+	Description     *string
+	DefaultMinScore *float64
+	ScorePolicy     *string
 }
 
 type CoursePreview struct {
@@ -30,4 +44,51 @@ type CoursePreview struct {
 	AuthorName  string    `json:"author_name"`
 	LogoURL     string    `json:"logo_url"`
 	StarsCount  int       `json:"stars_count"`
+	// CompletionPercent is the viewer's progress, set in the list of subscribed courses
+	CompletionPercent *float64 `json:"completion_percent,omitempty"`
+}
+
+const (
+	CleanupTargetLogo        = "logo"
+	CleanupTargetLessonMedia = "lesson_media"
+	CleanupTargetSearchIndex = "search_index"
+	CleanupTargetSubmissions = "assignment_submissions"
+)
+
+// CleanupFailure is an object of a deleted course that could not be removed; it is stored until a retry succeeds
+type CleanupFailure struct {
+	ID          uuid.UUID `json:"id"`
+	CourseID    uuid.UUID `json:"course_id"`
+	Target      string    `json:"target"`
+	ObjectKey   string    `json:"object_key,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Error       string    `json:"error"`
+	Attempts    int       `json:"attempts"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastTriedAt time.Time `json:"last_tried_at"`
+}
+
+type CourseDeletionReport struct {
+	CourseID uuid.UUID        `json:"course_id"`
+	Failures []CleanupFailure `json:"failures"`
+}
+
+type CleanupRetryReport struct {
+	Retried  int              `json:"retried"`
+	Resolved int              `json:"resolved"`
+	Failures []CleanupFailure `json:"failures"`
+}
+
+// VisibleTo is the single visibility policy: public courses are open to everyone,
+// hidden ones only to their author
+func (c *Course) VisibleTo(viewerID uuid.UUID) bool {
+	return c.Status == StatusPublic || (viewerID != uuid.Nil && c.AuthorID == viewerID)
+}
+
+func IsScorePolicy(policy string) bool {
+	switch policy {
+	case ScorePolicyBest, ScorePolicyLatest, ScorePolicyAverage:
+		return true
+	}
+	return false
 }
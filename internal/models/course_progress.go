@@ -0,0 +1,33 @@
+package models
+
+import "github.com/google/uuid"
+
+// CourseProgress is the learner's completion of a course. A lesson with quizzes or assignments is completed
+// when it is passed, any other lesson once it is marked complete or viewed. Percentages count lessons.
+type CourseProgress struct {
+	CourseID         uuid.UUID         `json:"course_id"`
+	CompletedLessons int               `json:"completed_lessons"`
+	TotalLessons     int               `json:"total_lessons"`
+	Percent          float64           `json:"percent"`
+	Modules          []ModuleProgress  `json:"modules"`
+	NextLesson       *LessonCompletion `json:"next_lesson,omitempty"`
+}
+
+type ModuleProgress struct {
+	ModuleID         uuid.UUID          `json:"module_id"`
+	Title            string             `json:"title"`
+	CompletedLessons int                `json:"completed_lessons"`
+	TotalLessons     int                `json:"total_lessons"`
+	Percent          float64            `json:"percent"`
+	Lessons          []LessonCompletion `json:"lessons"`
+}
+
+// LessonCompletion is a lesson in the course outline with the learner's status, not_started without progress
+type LessonCompletion struct {
+	LessonID  uuid.UUID `json:"lesson_id"`
+	ModuleID  uuid.UUID `json:"module_id"`
+	Title     string    `json:"title"`
+	Graded    bool      `json:"graded"`
+	Status    string    `json:"status"`
+	Completed bool      `json:"completed"`
+}
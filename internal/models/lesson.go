@@ -12,8 +12,17 @@ const (
 	ContentTypeVideo = "video"
 	ContentTypeQuiz  = "quiz"
 
-	LessonStatusPassed = "passed"
-	LessonStatusFailed = "failed"
+	ContentTypeAssignment = "assignment"
+
+	LessonStatusPassed     = "passed"
+	LessonStatusFailed     = "failed"
+	LessonStatusInProgress = "in_progress"
+	LessonStatusCompleted  = "completed"
+
+	BlockStatusNotStarted = "not_started"
+	BlockStatusPending    = "pending"
+
+	AttemptStatusInProgress = "in_progress"
 )
 
 type Lesson struct {
@@ -22,59 +31,101 @@ type Lesson struct {
 	ModuleID    uuid.UUID `json:"module_id"`
 	LessonTitle string    `json:"lesson_title"`
 	LessonOrder int       `json:"lesson_order"`
+	FreePreview bool      `json:"free_preview"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type CourseContent struct {
-	ID        uuid.UUID `json:"id"`
-	LessonID  uuid.UUID `json:"lesson_id"`
-	Type      string    `json:"type"`
-	Order     int       `json:"order"`
-	Text      *string   `json:"text,omitempty"`
-	ObjectKey *string   `json:"object_key,omitempty"`
-	QuizJSON  *string   `json:"quiz_json,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             uuid.UUID `json:"id"`
+	LessonID       uuid.UUID `json:"lesson_id"`
+	Type           string    `json:"type"`
+	Order          int       `json:"order"`
+	Text           *string   `json:"text,omitempty"`
+	ObjectKey      *string   `json:"object_key,omitempty"`
+	QuizJSON       *string   `json:"quiz_json,omitempty"`
+	AssignmentJSON *string   `json:"assignment_json,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 type LessonProgress struct {
-	UserID    uuid.UUID `json:"user_id"`
-	LessonID  uuid.UUID `json:"lesson_id"`
+	UserID    uuid.UUID     `json:"user_id"`
+	LessonID  uuid.UUID     `json:"lesson_id"`
+	Status    string        `json:"status"`
+	Score     float64       `json:"score"`
+	MinScore  float64       `json:"min_score"`
+	Blocks    []BlockResult `json:"blocks"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// BlockResult is the learner's result on one graded block of a lesson, a quiz under the course
+// score policy or the latest graded assignment submission
+type BlockResult struct {
+	ContentID uuid.UUID `json:"content_id"`
+	Type      string    `json:"type"`
 	Status    string    `json:"status"`
 	Score     float64   `json:"score"`
-	UpdatedAt time.Time `json:"updated_at"`
+	MinScore  float64   `json:"min_score"`
 }
 
-type LessonDetail struct {
-	Lesson   Lesson          `json:"lesson"`
-	Contents []CourseContent `json:"contents"`
+type QuestionResult struct {
+	QuestionID string  `json:"question_id"`
+	Correct    bool    `json:"correct"`
+	Points     float64 `json:"points"`
+	MaxPoints  float64 `json:"max_points"`
+}
+
+type QuizAttempt struct {
+	ID          uuid.UUID        `json:"id"`
+	UserID      uuid.UUID        `json:"user_id"`
+	Username    string           `json:"username,omitempty"`
+	LessonID    uuid.UUID        `json:"lesson_id"`
+	ContentID   uuid.UUID        `json:"content_id"`
+	Answers     []QuizAnswer     `json:"answers"`
+	Questions   []QuestionResult `json:"questions"`
+	Score       float64          `json:"score"`
+	MinScore    float64          `json:"min_score"`
+	Status      string           `json:"status"`
+	Late        bool             `json:"late"`
+	Served      []ServedQuestion `json:"served,omitempty"`
+	StartedAt   time.Time        `json:"started_at"`
+	Deadline    *time.Time       `json:"deadline,omitempty"`
+	SubmittedAt *time.Time       `json:"submitted_at,omitempty"`
 }
 
-type QuizJSON struct {
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	Questions   []QuizQuestion `json:"questions"`
-	MinScore    float64        `json:"minScore"`
+type QuizResult struct {
+	ContentID   uuid.UUID          `json:"content_id"`
+	AttemptID   *uuid.UUID         `json:"attempt_id,omitempty"`
+	ScorePolicy string             `json:"score_policy,omitempty"`
+	Attempts    int                `json:"attempts,omitempty"`
+	Late        bool               `json:"late,omitempty"`
+	Points      float64            `json:"points"`
+	MaxPoints   float64            `json:"max_points"`
+	Score       float64            `json:"score"`
+	MinScore    float64            `json:"min_score"`
+	Status      string             `json:"status"`
+	Questions   []QuestionFeedback `json:"questions"`
+	// LessonStatus is the lesson completion after the submission, set on submit only
+	LessonStatus string `json:"lesson_status,omitempty"`
 }
 
-type QuizQuestion struct {
-	ID            string       `json:"id"`
-	Text          string       `json:"text"`
-	Type          string       `json:"type"`
-	Options       []QuizOption `json:"options"`
-	Required      bool         `json:"required"`
-	CorrectAnswer string       `json:"correctAnswer"`
+// QuestionFeedback explains a graded question to the learner; CorrectAnswer is set only when
+// the quiz reveal policy allows it
+type QuestionFeedback struct {
+	QuestionResult
+	Answer             *QuizAnswer         `json:"answer,omitempty"`
+	Explanation        string              `json:"explanation,omitempty"`
+	OptionExplanations []OptionExplanation `json:"option_explanations,omitempty"`
+	CorrectAnswer      *QuizAnswer         `json:"correct_answer,omitempty"`
 }
 
-type QuizOption struct {
-	ID        string `json:"id"`
-	Text      string `json:"text"`
-	IsCorrect bool   `json:"isCorrect"`
+type OptionExplanation struct {
+	OptionID    string `json:"option_id"`
+	Explanation string `json:"explanation"`
 }
 
-type QuizAnswer struct {
-	QuestionID string   `json:"question_id"`
-	OptionIDs  []string `json:"option_ids,omitempty"`
-	TextAnswer string   `json:"text_answer,omitempty"`
+type LessonDetail struct {
+	Lesson   Lesson          `json:"lesson"`
+	Contents []CourseContent `json:"contents"`
 }
@@ -0,0 +1,267 @@
+package models
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+const (
+	QuizSchemaVersion = 1
+
+	QuestionTypeSingle   = "single"
+	QuestionTypeMultiple = "multiple"
+	QuestionTypeText     = "text"
+	QuestionTypeNumeric  = "numeric"
+	QuestionTypeOrdering = "ordering"
+	QuestionTypeMatching = "matching"
+	QuestionTypeCloze    = "cloze"
+
+	ScoringAllOrNothing    = "all_or_nothing"
+	ScoringProportional    = "proportional"
+	ScoringRightMinusWrong = "right_minus_wrong"
+
+	LateSubmissionReject = "reject"
+	LateSubmissionGrade  = "grade"
+
+	RevealNever            = "never"
+	RevealAfterSubmit      = "after_submit"
+	RevealAfterPassing     = "after_passing"
+	RevealAfterLastAttempt = "after_last_attempt"
+)
+
+type QuizJSON struct {
+	Version     int            `json:"version"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Questions   []QuizQuestion `json:"questions"`
+	MinScore    *float64       `json:"minScore,omitempty"`
+	QuizSettings
+}
+
+// QuizSettings limit how learners may take a quiz, zero values mean no limit
+type QuizSettings struct {
+	MaxAttempts      int    `json:"maxAttempts,omitempty"`
+	CooldownSeconds  int    `json:"cooldownSeconds,omitempty"`
+	TimeLimitSeconds int    `json:"timeLimitSeconds,omitempty"`
+	LateSubmission   string `json:"lateSubmission,omitempty"`
+	DrawCount        int    `json:"drawCount,omitempty"`
+	ShuffleQuestions bool   `json:"shuffleQuestions,omitempty"`
+	ShuffleOptions   bool   `json:"shuffleOptions,omitempty"`
+	RevealAnswers    string `json:"revealAnswers,omitempty"`
+}
+
+func (s QuizSettings) Timed() bool {
+	return s.TimeLimitSeconds > 0
+}
+
+// Randomized quizzes serve each attempt its own selection and order of questions
+func (s QuizSettings) Randomized() bool {
+	return s.DrawCount > 0 || s.ShuffleQuestions || s.ShuffleOptions
+}
+
+// RequiresStart tells whether learners must open an attempt before answering
+func (s QuizSettings) RequiresStart() bool {
+	return s.Timed() || s.Randomized()
+}
+
+type QuizQuestion struct {
+	ID              string       `json:"id"`
+	Text            string       `json:"text"`
+	Type            string       `json:"type"`
+	Options         []QuizOption `json:"options"`
+	Required        bool         `json:"required"`
+	CorrectAnswer   string       `json:"correctAnswer"`
+	AcceptedAnswers []string     `json:"acceptedAnswers,omitempty"`
+	Pattern         string       `json:"pattern,omitempty"`
+	NumericAnswer   *float64     `json:"numericAnswer,omitempty"`
+	Tolerance       float64      `json:"tolerance,omitempty"`
+	Units           []string     `json:"units,omitempty"`
+	Pairs           []QuizPair   `json:"pairs,omitempty"`
+	Blanks          []QuizBlank  `json:"blanks,omitempty"`
+	Points          float64      `json:"points,omitempty"`
+	Scoring         string       `json:"scoring,omitempty"`
+	Explanation     string       `json:"explanation,omitempty"`
+}
+
+// MaxPoints is the question weight, questions without one are worth a single point
+func (q QuizQuestion) MaxPoints() float64 {
+	if q.Points > 0 {
+		return q.Points
+	}
+	return 1
+}
+
+type QuizOption struct {
+	ID          string `json:"id"`
+	Text        string `json:"text"`
+	IsCorrect   bool   `json:"isCorrect"`
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// QuizPair is one row of a matching question: the prompt and the item it must be matched with
+type QuizPair struct {
+	ID      string `json:"id"`
+	Prompt  string `json:"prompt"`
+	MatchID string `json:"matchId"`
+	Match   string `json:"match"`
+}
+
+// QuizBlank is a gap of a cloze question, marked as {{id}} in the question text
+type QuizBlank struct {
+	ID              string   `json:"id"`
+	AcceptedAnswers []string `json:"acceptedAnswers,omitempty"`
+	Pattern         string   `json:"pattern,omitempty"`
+}
+
+// QuizAnswer holds the learner's answer to one question. Choice and ordering questions use OptionIDs
+// (for ordering in the chosen sequence), numeric ones NumberAnswer with Unit, matching ones Matches
+// and cloze ones Blanks keyed by blank id. Clients may report the time spent on the question for analytics.
+type QuizAnswer struct {
+	QuestionID   string            `json:"question_id"`
+	OptionIDs    []string          `json:"option_ids,omitempty"`
+	TextAnswer   string            `json:"text_answer,omitempty"`
+	NumberAnswer *float64          `json:"number_answer,omitempty"`
+	Unit         string            `json:"unit,omitempty"`
+	Matches      []MatchAnswer     `json:"matches,omitempty"`
+	Blanks       map[string]string `json:"blanks,omitempty"`
+	TimeSpent    int               `json:"time_spent_seconds,omitempty"`
+}
+
+// ServedQuestion records a question shown in an attempt and the order its options were shown in
+type ServedQuestion struct {
+	QuestionID string   `json:"question_id"`
+	OptionIDs  []string `json:"option_ids,omitempty"`
+}
+
+type StartedQuiz struct {
+	Attempt QuizAttempt `json:"attempt"`
+	Quiz    LearnerQuiz `json:"quiz"`
+}
+
+type MatchAnswer struct {
+	PromptID string `json:"prompt_id"`
+	MatchID  string `json:"match_id"`
+}
+
+// LearnerQuiz is the quiz as shown to learners: the same questions without the answer key
+type LearnerQuiz struct {
+	Version     int               `json:"version"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Questions   []LearnerQuestion `json:"questions"`
+	MinScore    *float64          `json:"minScore,omitempty"`
+	QuizSettings
+}
+
+type LearnerQuestion struct {
+	ID       string          `json:"id"`
+	Text     string          `json:"text"`
+	Type     string          `json:"type"`
+	Options  []LearnerOption `json:"options"`
+	Prompts  []LearnerOption `json:"prompts,omitempty"`
+	Matches  []LearnerOption `json:"matches,omitempty"`
+	Blanks   []string        `json:"blanks,omitempty"`
+	Units    []string        `json:"units,omitempty"`
+	Required bool            `json:"required"`
+	Points   float64         `json:"points"`
+	Scoring  string          `json:"scoring,omitempty"`
+}
+
+type LearnerOption struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+func (q QuizJSON) LearnerView() LearnerQuiz {
+	view := LearnerQuiz{
+		Version:      q.Version,
+		Title:        q.Title,
+		Description:  q.Description,
+		Questions:    make([]LearnerQuestion, 0, len(q.Questions)),
+		MinScore:     q.MinScore,
+		QuizSettings: q.QuizSettings,
+	}
+	for _, question := range q.Questions {
+		lq := LearnerQuestion{
+			ID:       question.ID,
+			Text:     question.Text,
+			Type:     question.Type,
+			Options:  make([]LearnerOption, 0, len(question.Options)),
+			Required: question.Required,
+			Points:   question.MaxPoints(),
+			Scoring:  question.Scoring,
+		}
+		for _, option := range question.Options {
+			lq.Options = append(lq.Options, LearnerOption{ID: option.ID, Text: option.Text})
+		}
+		for _, pair := range question.Pairs {
+			lq.Prompts = append(lq.Prompts, LearnerOption{ID: pair.ID, Text: pair.Prompt})
+			lq.Matches = append(lq.Matches, LearnerOption{ID: pair.MatchID, Text: pair.Match})
+		}
+		for _, blank := range question.Blanks {
+			lq.Blanks = append(lq.Blanks, blank.ID)
+		}
+		if question.Type == QuestionTypeNumeric {
+			lq.Units = question.Units
+		}
+		// the stored order is the answer key of ordering and matching questions
+		if question.Type == QuestionTypeOrdering {
+			scramble(lq.Options)
+		}
+		scramble(lq.Matches)
+		view.Questions = append(view.Questions, lq)
+	}
+	return view
+}
+
+// scramble reorders options by a hash of their ids, so the order is stable but unrelated to the
+// stored one even when authors number their ids
+func scramble(options []LearnerOption) {
+	key := func(id string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(id))
+		return h.Sum64()
+	}
+	sort.Slice(options, func(i, j int) bool { return key(options[i].ID) < key(options[j].ID) })
+}
+
+// Served keeps only the questions served in an attempt, in the order they were served.
+// Options stay in the stored order because that order is the answer key of ordering questions.
+func (q QuizJSON) Served(served []ServedQuestion) QuizJSON {
+	if served == nil {
+		return q
+	}
+	byID := make(map[string]QuizQuestion, len(q.Questions))
+	for _, question := range q.Questions {
+		byID[question.ID] = question
+	}
+	q.Questions = make([]QuizQuestion, 0, len(served))
+	for _, s := range served {
+		if question, ok := byID[s.QuestionID]; ok {
+			q.Questions = append(q.Questions, question)
+		}
+	}
+	return q
+}
+
+// ServedView is the learner view of an attempt: its questions with the options in the served order
+func (q QuizJSON) ServedView(served []ServedQuestion) LearnerQuiz {
+	view := q.Served(served).LearnerView()
+	optionOrder := make(map[string][]string, len(served))
+	for _, s := range served {
+		optionOrder[s.QuestionID] = s.OptionIDs
+	}
+	for i := range view.Questions {
+		order := optionOrder[view.Questions[i].ID]
+		if len(order) == 0 {
+			continue
+		}
+		position := make(map[string]int, len(order))
+		for p, id := range order {
+			position[id] = p
+		}
+		options := view.Questions[i].Options
+		sort.SliceStable(options, func(a, b int) bool { return position[options[a].ID] < position[options[b].ID] })
+	}
+	return view
+}
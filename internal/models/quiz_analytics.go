@@ -0,0 +1,51 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	QuestionFlagTooHard              = "too_hard"
+	QuestionFlagTooEasy              = "too_easy"
+	QuestionFlagLowDiscrimination    = "low_discrimination"
+	QuestionFlagUnusedDistractor     = "unused_distractor"
+	QuestionFlagDistractorOverChosen = "distractor_over_chosen"
+)
+
+// QuizAnalytics summarizes the submitted attempts at a quiz block. Rates are percentages; rates and
+// averages with nothing to compute them from are omitted.
+type QuizAnalytics struct {
+	ContentID              uuid.UUID           `json:"content_id"`
+	Attempts               int                 `json:"attempts"`
+	Learners               int                 `json:"learners"`
+	AverageScore           float64             `json:"average_score"`
+	PassRate               float64             `json:"pass_rate"`
+	FirstAttemptPassRate   float64             `json:"first_attempt_pass_rate"`
+	AverageDurationSeconds *float64            `json:"average_duration_seconds,omitempty"`
+	Questions              []QuestionAnalytics `json:"questions"`
+}
+
+// QuestionAnalytics describes how learners did on one question of the current quiz. Served counts the
+// attempts it appeared in, the base of its rates. Discrimination is the difference between the correct
+// rates of the top and bottom 27% of those attempts by score, from -1 to 1.
+type QuestionAnalytics struct {
+	QuestionID              string            `json:"question_id"`
+	Text                    string            `json:"text"`
+	Type                    string            `json:"type"`
+	Served                  int               `json:"served"`
+	Answered                int               `json:"answered"`
+	CorrectRate             float64           `json:"correct_rate"`
+	AverageCredit           float64           `json:"average_credit"`
+	FirstAttemptCorrectRate *float64          `json:"first_attempt_correct_rate,omitempty"`
+	Discrimination          *float64          `json:"discrimination,omitempty"`
+	AverageTimeSeconds      *float64          `json:"average_time_seconds,omitempty"`
+	Options                 []OptionAnalytics `json:"options,omitempty"`
+	Flags                   []string          `json:"flags"`
+}
+
+// OptionAnalytics is how often a choice option was selected in the attempts that served its question
+type OptionAnalytics struct {
+	OptionID      string  `json:"option_id"`
+	Text          string  `json:"text"`
+	IsCorrect     bool    `json:"is_correct"`
+	Selected      int     `json:"selected"`
+	SelectionRate float64 `json:"selection_rate"`
+}
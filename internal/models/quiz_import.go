@@ -0,0 +1,31 @@
+package models
+
+const (
+	ImportFormatGIFT      = "gift"
+	ImportFormatMoodleXML = "moodle_xml"
+	ImportFormatQTI       = "qti"
+)
+
+// QuizImport is a quiz converted from another LMS. Items that could not be converted are left out
+// and listed in Issues; Content is set once the quiz is saved to a lesson.
+type QuizImport struct {
+	Format   string         `json:"format"`
+	Quiz     QuizJSON       `json:"quiz"`
+	Imported int            `json:"imported"`
+	Skipped  int            `json:"skipped"`
+	Issues   []ImportIssue  `json:"issues"`
+	Valid    bool           `json:"valid"`
+	Content  *CourseContent `json:"content,omitempty"`
+}
+
+// ImportIssue describes a problem with one source item (Item is its 1-based position in the file).
+// Skipped items are missing from the quiz, the others were imported with a loss noted in Message.
+// Issues found by quiz validation carry Field instead of Item.
+type ImportIssue struct {
+	Item    int    `json:"item,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	Skipped bool   `json:"skipped"`
+}
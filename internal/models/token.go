@@ -7,13 +7,51 @@ import (
 )
 
 type RefreshToken struct {
+	ID          uuid.UUID
+	FamilyID    uuid.UUID
 	UserID      uuid.UUID
 	HashedToken string
+	DeviceName  string
+	UserAgent   string
+	IP          string
 	CreatedAt   time.Time
+	LastUsedAt  time.Time
 	ExpiresAt   time.Time
+	RotatedAt   *time.Time
+}
+
+const (
+	ActionPasswordReset = "password_reset"
+	ActionEmailVerify   = "email_verify"
+)
+
+type ActionToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
 }
 
 type TokenPair struct {
 	AccessToken  *jwt.Token
 	RefreshToken *jwt.Token
 }
+
+type SessionInfo struct {
+	DeviceName string
+	UserAgent  string
+	IP         string
+}
+
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
@@ -1,6 +1,9 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 const (
 	ClientRole = "client"
@@ -9,9 +12,46 @@ const (
 )
 
 type User struct {
-	ID       uuid.UUID
-	Username string
-	Password string
-	Email    string
-	Roles    []string
+	ID            uuid.UUID
+	Username      string
+	Password      string
+	Email         string
+	EmailVerified bool
+	Blocked       bool
+	Roles         []string
+}
+
+type PlatformStats struct {
+	Users          int `json:"users"`
+	BlockedUsers   int `json:"blocked_users"`
+	Clients        int `json:"clients"`
+	Authors        int `json:"authors"`
+	Admins         int `json:"admins"`
+	Courses        int `json:"courses"`
+	PublicCourses  int `json:"public_courses"`
+	HiddenCourses  int `json:"hidden_courses"`
+	Modules        int `json:"modules"`
+	Lessons        int `json:"lessons"`
+	Subscriptions  int `json:"subscriptions"`
+	Ratings        int `json:"ratings"`
+	PassedLessons  int `json:"passed_lessons"`
+	ActiveSessions int `json:"active_sessions"`
+}
+
+const (
+	ApplicationPending  = "pending"
+	ApplicationApproved = "approved"
+	ApplicationRejected = "rejected"
+)
+
+type AuthorApplication struct {
+	ID              uuid.UUID  `json:"id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	Username        string     `json:"username,omitempty"`
+	Motivation      string     `json:"motivation"`
+	Status          string     `json:"status"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	ReviewedBy      *uuid.UUID `json:"reviewed_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
 }
@@ -0,0 +1,170 @@
+package admin
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"SkillForge/pkg/logger"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type userRepo interface {
+	UserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	ListUsers(ctx context.Context, search, role string, limit, offset int) ([]models.User, int, error)
+	AddRole(ctx context.Context, userID uuid.UUID, roleName string) error
+	RemoveRole(ctx context.Context, userID uuid.UUID, roleName string) error
+	SetBlocked(ctx context.Context, userID uuid.UUID, blocked bool) error
+}
+
+type tokenRepo interface {
+	DeleteUserTokens(ctx context.Context, userID uuid.UUID) error
+}
+
+type courseRepo interface {
+	CourseByID(ctx context.Context, id uuid.UUID) (*models.Course, error)
+	ChangeStatus(ctx context.Context, id uuid.UUID, status string) error
+}
+
+type courseCleaner interface {
+	DeleteCourse(ctx context.Context, course *models.Course) (*models.CourseDeletionReport, error)
+	CleanupFailures(ctx context.Context) ([]models.CleanupFailure, error)
+	RetryCleanup(ctx context.Context) (*models.CleanupRetryReport, error)
+}
+
+type searchRepo interface {
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type statsRepo interface {
+	PlatformStats(ctx context.Context) (models.PlatformStats, error)
+}
+
+type AdminService struct {
+	log        logger.Log
+	userRepo   userRepo
+	tokenRepo  tokenRepo
+	courseRepo courseRepo
+	cleaner    courseCleaner
+	searchRepo searchRepo
+	statsRepo  statsRepo
+}
+
+func NewAdminService(log logger.Log, u userRepo, t tokenRepo, c courseRepo, cl courseCleaner, s searchRepo, st statsRepo) *AdminService {
+	return &AdminService{
+		log:        log,
+		userRepo:   u,
+		tokenRepo:  t,
+		courseRepo: c,
+		cleaner:    cl,
+		searchRepo: s,
+		statsRepo:  st,
+	}
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, search, role string, limit, offset int) ([]models.User, int, error) {
+	return s.userRepo.ListUsers(ctx, search, role, limit, offset)
+}
+
+func (s *AdminService) GrantRole(ctx context.Context, adminID, userID uuid.UUID, role string) error {
+	if !isKnownRole(role) {
+		return app_errors.ErrRoleNotFound
+	}
+	if _, err := s.userRepo.UserByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.userRepo.AddRole(ctx, userID, role); err != nil {
+		return err
+	}
+	s.log.Info("admin granted role", "admin_id", adminID.String(), "user_id", userID.String(), "role", role)
+	return nil
+}
+
+func (s *AdminService) RevokeRole(ctx context.Context, adminID, userID uuid.UUID, role string) error {
+	if !isKnownRole(role) {
+		return app_errors.ErrRoleNotFound
+	}
+	if adminID == userID && role == models.AdminRole {
+		return app_errors.ErrSelfModeration
+	}
+	if _, err := s.userRepo.UserByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.userRepo.RemoveRole(ctx, userID, role); err != nil {
+		return err
+	}
+	s.log.Info("admin revoked role", "admin_id", adminID.String(), "user_id", userID.String(), "role", role)
+	return nil
+}
+
+func (s *AdminService) BlockUser(ctx context.Context, adminID, userID uuid.UUID) error {
+	if adminID == userID {
+		return app_errors.ErrSelfModeration
+	}
+	if err := s.userRepo.SetBlocked(ctx, userID, true); err != nil {
+		return err
+	}
+	if err := s.tokenRepo.DeleteUserTokens(ctx, userID); err != nil {
+		return err
+	}
+	s.log.Info("admin blocked user", "admin_id", adminID.String(), "user_id", userID.String())
+	return nil
+}
+
+func (s *AdminService) UnblockUser(ctx context.Context, adminID, userID uuid.UUID) error {
+	if err := s.userRepo.SetBlocked(ctx, userID, false); err != nil {
+		return err
+	}
+	s.log.Info("admin unblocked user", "admin_id", adminID.String(), "user_id", userID.String())
+	return nil
+}
+
+func (s *AdminService) ForceHideCourse(ctx context.Context, adminID, courseID uuid.UUID) error {
+	if err := s.courseRepo.ChangeStatus(ctx, courseID, models.StatusHidden); err != nil {
+		return err
+	}
+	if err := s.searchRepo.Delete(ctx, courseID); err != nil {
+		s.log.ErrorErr("failed to remove hidden course from search index", err, "course_id", courseID.String())
+	}
+	s.log.Info("admin hid course", "admin_id", adminID.String(), "course_id", courseID.String())
+	return nil
+}
+
+func (s *AdminService) ForceDeleteCourse(ctx context.Context, adminID, courseID uuid.UUID) (*models.CourseDeletionReport, error) {
+	course, err := s.courseRepo.CourseByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.cleaner.DeleteCourse(ctx, course)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("admin deleted course", "admin_id", adminID.String(), "course_id", courseID.String())
+	return report, nil
+}
+
+func (s *AdminService) CleanupFailures(ctx context.Context) ([]models.CleanupFailure, error) {
+	return s.cleaner.CleanupFailures(ctx)
+}
+
+func (s *AdminService) RetryCleanup(ctx context.Context, adminID uuid.UUID) (*models.CleanupRetryReport, error) {
+	report, err := s.cleaner.RetryCleanup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("admin retried course cleanup", "admin_id", adminID.String(),
+		"retried", report.Retried, "resolved", report.Resolved)
+	return report, nil
+}
+
+func (s *AdminService) PlatformStats(ctx context.Context) (models.PlatformStats, error) {
+	return s.statsRepo.PlatformStats(ctx)
+}
+
+func isKnownRole(role string) bool {
+	switch role {
+	case models.ClientRole, models.AuthorRole, models.AdminRole:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package application
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"SkillForge/pkg/logger"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type applicationRepo interface {
+	Create(ctx context.Context, app models.AuthorApplication) (*models.AuthorApplication, error)
+	ApplicationByID(ctx context.Context, id uuid.UUID) (*models.AuthorApplication, error)
+	UserApplications(ctx context.Context, userID uuid.UUID) ([]models.AuthorApplication, error)
+	ListApplications(ctx context.Context, status string, limit, offset int) ([]models.AuthorApplication, int, error)
+	Review(ctx context.Context, id, reviewerID uuid.UUID, status, reason string) error
+}
+
+type userRepo interface {
+	UserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	AddRole(ctx context.Context, userID uuid.UUID, roleName string) error
+}
+
+type mailSender interface {
+	Send(ctx context.Context, msg models.MailMessage) error
+}
+
+type AuthorApplicationService struct {
+	log        logger.Log
+	appRepo    applicationRepo
+	userRepo   userRepo
+	mailSender mailSender
+}
+
+func NewAuthorApplicationService(log logger.Log, a applicationRepo, u userRepo, m mailSender) *AuthorApplicationService {
+	return &AuthorApplicationService{
+		log:        log,
+		appRepo:    a,
+		userRepo:   u,
+		mailSender: m,
+	}
+}
+
+func (s *AuthorApplicationService) ApplyForAuthor(ctx context.Context, userID uuid.UUID, motivation string) (*models.AuthorApplication, error) {
+	user, err := s.userRepo.UserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(user.Roles, models.AuthorRole) {
+		return nil, app_errors.ErrAlreadyAuthor
+	}
+
+	app, err := s.appRepo.Create(ctx, models.AuthorApplication{
+		UserID:     userID,
+		Motivation: strings.TrimSpace(motivation),
+	})
+	if err != nil {
+		return nil, err
+	}
+	app.Username = user.Username
+	s.log.Info("author application submitted", "application_id", app.ID.String(), "user_id", userID.String())
+	return app, nil
+}
+
+func (s *AuthorApplicationService) MyApplications(ctx context.Context, userID uuid.UUID) ([]models.AuthorApplication, error) {
+	return s.appRepo.UserApplications(ctx, userID)
+}
+
+func (s *AuthorApplicationService) ListApplications(ctx context.Context, status string, limit, offset int) ([]models.AuthorApplication, int, error) {
+	return s.appRepo.ListApplications(ctx, status, limit, offset)
+}
+
+func (s *AuthorApplicationService) ApproveApplication(ctx context.Context, adminID, applicationID uuid.UUID) error {
+	app, err := s.pendingApplication(ctx, applicationID)
+	if err != nil {
+		return err
+	}
+	// the role is granted first: approving twice is harmless, a recorded approval without the role is not
+	if err := s.userRepo.AddRole(ctx, app.UserID, models.AuthorRole); err != nil {
+		return err
+	}
+	if err := s.appRepo.Review(ctx, app.ID, adminID, models.ApplicationApproved, ""); err != nil {
+		return err
+	}
+	s.log.Info("author application approved", "application_id", app.ID.String(), "admin_id", adminID.String())
+
+	s.notify(ctx, app.UserID, "Your author application is approved",
+		"your application to become an author on SkillForge has been approved. You can now create courses.")
+	return nil
+}
+
+func (s *AuthorApplicationService) RejectApplication(ctx context.Context, adminID, applicationID uuid.UUID, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return app_errors.ErrRejectionReasonRequired
+	}
+	app, err := s.pendingApplication(ctx, applicationID)
+	if err != nil {
+		return err
+	}
+	if err := s.appRepo.Review(ctx, app.ID, adminID, models.ApplicationRejected, reason); err != nil {
+		return err
+	}
+	s.log.Info("author application rejected", "application_id", app.ID.String(), "admin_id", adminID.String())
+
+	s.notify(ctx, app.UserID, "Your author application is rejected",
+		fmt.Sprintf("your application to become an author on SkillForge has been rejected.\n\nReason: %s\n\n"+
+			"You can submit a new application at any time.", reason))
+	return nil
+}
+
+func (s *AuthorApplicationService) pendingApplication(ctx context.Context, id uuid.UUID) (*models.AuthorApplication, error) {
+	app, err := s.appRepo.ApplicationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status != models.ApplicationPending {
+		return nil, app_errors.ErrApplicationReviewed
+	}
+	return app, nil
+}
+
+// notify is best effort: the decision is already stored and visible via the API
+func (s *AuthorApplicationService) notify(ctx context.Context, userID uuid.UUID, subject, text string) {
+	user, err := s.userRepo.UserByID(ctx, userID)
+	if err != nil {
+		s.log.ErrorErr("failed to load applicant for notification", err, "user_id", userID.String())
+		return
+	}
+	err = s.mailSender.Send(ctx, models.MailMessage{
+		To:      user.Email,
+		Subject: subject,
+		Body:    fmt.Sprintf("Hello, %s!\n\n%s", user.Username, text),
+	})
+	if err != nil {
+		s.log.ErrorErr("failed to send application notification", err, "user_id", userID.String())
+	}
+}
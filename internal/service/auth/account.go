@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (u *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := u.authRepo.UserByEmail(ctx, email)
+	if err != nil {
+		// do not reveal whether the address is registered
+		if errors.Is(err, app_errors.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// failures past this point only happen for registered addresses, so they are logged instead of returned
+	token, err := u.issueActionToken(ctx, user.ID, models.ActionPasswordReset, u.opts.PasswordResetTTL)
+	if err != nil {
+		u.log.ErrorErr("failed to issue password reset token", err, "user_id", user.ID.String())
+		return nil
+	}
+	link := u.actionLink("/reset-password", token)
+	err = u.mailSender.Send(ctx, models.MailMessage{
+		To:      user.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Hello, %s!\n\nTo set a new password follow the link below:\n%s\n\n"+
+			"The link is valid for %s and can be used once. If you did not request a reset, ignore this email.",
+			user.Username, link, u.opts.PasswordResetTTL),
+	})
+	if err != nil {
+		u.log.ErrorErr("failed to send password reset email", err, "user_id", user.ID.String())
+	}
+	return nil
+}
+
+func (u *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+	actionToken, err := u.consumeActionToken(ctx, token, models.ActionPasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := u.authRepo.UpdatePassword(ctx, actionToken.UserID, hashed); err != nil {
+		return err
+	}
+	// the old password may be compromised, so every device has to log in again
+	if err := u.tokenRepo.DeleteUserTokens(ctx, actionToken.UserID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ResendEmailVerification is open to users who cannot log in yet; unknown and already verified addresses
+// are ignored so the answer does not reveal whether an account exists
+func (u *AuthService) ResendEmailVerification(ctx context.Context, email string) error {
+	user, err := u.authRepo.UserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, app_errors.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.EmailVerified {
+		return nil
+	}
+	if err := u.sendEmailVerification(ctx, user); err != nil {
+		u.log.ErrorErr("failed to resend verification email", err, "user_id", user.ID.String())
+	}
+	return nil
+}
+
+func (u *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	actionToken, err := u.consumeActionToken(ctx, token, models.ActionEmailVerify)
+	if err != nil {
+		return err
+	}
+	return u.authRepo.SetEmailVerified(ctx, actionToken.UserID)
+}
+
+func (u *AuthService) sendEmailVerification(ctx context.Context, user *models.User) error {
+	token, err := u.issueActionToken(ctx, user.ID, models.ActionEmailVerify, u.opts.EmailVerifyTTL)
+	if err != nil {
+		return err
+	}
+	link := u.actionLink("/verify-email", token)
+	return u.mailSender.Send(ctx, models.MailMessage{
+		To:      user.Email,
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf("Hello, %s!\n\nPlease confirm your email address by following the link below:\n%s\n\n"+
+			"The link is valid for %s.", user.Username, link, u.opts.EmailVerifyTTL),
+	})
+}
+
+func (u *AuthService) issueActionToken(ctx context.Context, userID uuid.UUID, purpose string, ttl time.Duration) (string, error) {
+	record, err := u.actionTokenRepo.Create(ctx, models.ActionToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return u.jwtManager.GenerateActionToken(userID, record.ID, purpose, record.ExpiresAt)
+}
+
+func (u *AuthService) consumeActionToken(ctx context.Context, token, purpose string) (*models.ActionToken, error) {
+	claims, err := u.jwtManager.ActionClaims(token, purpose)
+	if err != nil {
+		return nil, err
+	}
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, app_errors.ErrInvalidActionToken
+	}
+	actionToken, err := u.actionTokenRepo.Consume(ctx, tokenID, purpose)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject != actionToken.UserID.String() {
+		return nil, app_errors.ErrInvalidActionToken
+	}
+	return actionToken, nil
+}
+
+func (u *AuthService) actionLink(path, token string) string {
+	return strings.TrimRight(u.opts.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
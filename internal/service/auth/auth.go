@@ -5,6 +5,7 @@ import (
 	"SkillForge/internal/models"
 	"SkillForge/pkg/logger"
 	"context"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -16,66 +17,150 @@ type AuthRepo interface {
 	CreateUser(ctx context.Context, user models.User) (*models.User, error)
 	UserByName(ctx context.Context, username string) (*models.User, error)
 	UserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	UserByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error
+	SetEmailVerified(ctx context.Context, userID uuid.UUID) error
 }
 
 type tokenRepo interface {
-	Create(ctx context.Context, userID uuid.UUID, token *jwt.Token) (*models.RefreshToken, error)
+	Create(ctx context.Context, session models.RefreshToken, token *jwt.Token) (*models.RefreshToken, error)
 	ByPrimaryKey(ctx context.Context, userID uuid.UUID, token *jwt.Token) (*models.RefreshToken, error)
+	Rotate(ctx context.Context, current models.RefreshToken, token *jwt.Token, info models.SessionInfo) (*models.RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+	UserSessions(ctx context.Context, userID uuid.UUID) ([]models.RefreshToken, error)
+	TouchSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	DeleteExpiredTokens(ctx context.Context, userID uuid.UUID) error
 	DeleteUserTokens(ctx context.Context, userID uuid.UUID) error
 }
 
+type actionTokenRepo interface {
+	Create(ctx context.Context, token models.ActionToken) (*models.ActionToken, error)
+	Consume(ctx context.Context, id uuid.UUID, purpose string) (*models.ActionToken, error)
+}
+
+type mailSender interface {
+	Send(ctx context.Context, msg models.MailMessage) error
+}
+
+type AccountOptions struct {
+	AppURL               string
+	RequireVerifiedEmail bool
+	PasswordResetTTL     time.Duration
+	EmailVerifyTTL       time.Duration
+}
+
 type AuthService struct {
-	log        logger.Log
-	jwtManager *JWTManager
-	authRepo   AuthRepo
-	tokenRepo  tokenRepo
+	log             logger.Log
+	jwtManager      *JWTManager
+	authRepo        AuthRepo
+	tokenRepo       tokenRepo
+	actionTokenRepo actionTokenRepo
+	mailSender      mailSender
+	opts            AccountOptions
 }
 
-func NewAuthService(l logger.Log, manager *JWTManager, aRepo AuthRepo, tRepo tokenRepo) *AuthService {
+func NewAuthService(l logger.Log, manager *JWTManager, aRepo AuthRepo, tRepo tokenRepo, actRepo actionTokenRepo, mail mailSender, opts AccountOptions) *AuthService {
 	return &AuthService{
-		log:        l,
-		jwtManager: manager,
-		authRepo:   aRepo,
-		tokenRepo:  tRepo,
+		log:             l,
+		jwtManager:      manager,
+		authRepo:        aRepo,
+		tokenRepo:       tRepo,
+		actionTokenRepo: actRepo,
+		mailSender:      mail,
+		opts:            opts,
 	}
 }
 
-func (u *AuthService) RefreshTokens(ctx context.Context, token string) (*models.TokenPair, error) {
-	curToken, err := u.jwtManager.Parse(token)
+func (u *AuthService) RefreshTokens(ctx context.Context, token string, info models.SessionInfo) (*models.TokenPair, error) {
+	tokenRecord, err := u.refreshTokenRecord(ctx, token)
 	if err != nil {
 		return nil, err
 	}
-	userIdStr, err := curToken.Claims.GetSubject()
+	user, err := u.authRepo.UserByID(ctx, tokenRecord.UserID)
 	if err != nil {
 		return nil, err
 	}
-	userID, err := uuid.Parse(userIdStr)
+	if user.Blocked {
+		return nil, app_errors.ErrUserBlocked
+	}
+	if tokenRecord.RotatedAt != nil {
+		return nil, u.revokeReusedFamily(ctx, tokenRecord, info)
+	}
+	if tokenRecord.ExpiresAt.Before(time.Now()) {
+		return nil, app_errors.ErrTokenExpired
+	}
+	tokenPair, err := u.jwtManager.GenerateTokenPair(user.ID, tokenRecord.FamilyID, user.Roles)
 	if err != nil {
 		return nil, err
 	}
-	tokenRecord, err := u.tokenRepo.ByPrimaryKey(ctx, userID, curToken)
-	if err != nil {
+	if _, err := u.tokenRepo.Rotate(ctx, *tokenRecord, tokenPair.RefreshToken, info); err != nil {
+		if errors.Is(err, app_errors.ErrTokenReused) {
+			u.reportTokenReuse(tokenRecord, info)
+		}
 		return nil, err
 	}
-	user, err := u.authRepo.UserByID(ctx, userID)
+	return tokenPair, nil
+
+}
+
+func (u *AuthService) Logout(ctx context.Context, token string) error {
+	tokenRecord, err := u.refreshTokenRecord(ctx, token)
+	if err != nil {
+		return err
+	}
+	if tokenRecord.RotatedAt != nil {
+		return u.revokeReusedFamily(ctx, tokenRecord, models.SessionInfo{})
+	}
+	return u.tokenRepo.DeleteSession(ctx, tokenRecord.UserID, tokenRecord.FamilyID)
+}
+
+func (u *AuthService) Sessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]models.Session, error) {
+	records, err := u.tokenRepo.UserSessions(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	if tokenRecord.ExpiresAt.Before(time.Now()) {
-		return nil, app_errors.ErrTokenExpired
+	sessions := make([]models.Session, 0, len(records))
+	for _, r := range records {
+		sessions = append(sessions, models.Session{
+			ID:         r.FamilyID,
+			DeviceName: r.DeviceName,
+			UserAgent:  r.UserAgent,
+			IP:         r.IP,
+			CreatedAt:  r.CreatedAt,
+			LastUsedAt: r.LastUsedAt,
+			ExpiresAt:  r.ExpiresAt,
+			Current:    r.FamilyID == currentSessionID,
+		})
 	}
-	tokenPair, err := u.jwtManager.GenerateTokenPair(user.ID, user.Roles)
+	return sessions, nil
+}
+
+func (u *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return u.tokenRepo.DeleteSession(ctx, userID, sessionID)
+}
+
+func (u *AuthService) TouchSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return u.tokenRepo.TouchSession(ctx, userID, sessionID)
+}
+
+func (u *AuthService) refreshTokenRecord(ctx context.Context, token string) (*models.RefreshToken, error) {
+	curToken, err := u.jwtManager.Parse(token)
 	if err != nil {
 		return nil, err
 	}
-	if err := u.tokenRepo.DeleteUserTokens(ctx, userID); err != nil {
+	if !u.jwtManager.TokenType(curToken, RefreshTokenType) {
+		return nil, app_errors.ErrTokenNotFound
+	}
+	userIdStr, err := curToken.Claims.GetSubject()
+	if err != nil {
 		return nil, err
 	}
-	if _, err := u.tokenRepo.Create(ctx, user.ID, tokenPair.RefreshToken); err != nil {
+	userID, err := uuid.Parse(userIdStr)
+	if err != nil {
 		return nil, err
 	}
-	return tokenPair, nil
-
+	return u.tokenRepo.ByPrimaryKey(ctx, userID, curToken)
 }
 
 func (u *AuthService) ParseToken(ctx context.Context, token string) (*jwt.Token, error) {
@@ -86,13 +171,14 @@ func (u *AuthService) IsAccessToken(ctx context.Context, token *jwt.Token) bool
 	return u.jwtManager.TokenType(token, AccessTokenType)
 }
 
-func (u *AuthService) AccessClaims(ctx context.Context, token string) (userID uuid.UUID, roles []string, err error) {
+func (u *AuthService) AccessClaims(ctx context.Context, token string) (userID uuid.UUID, roles []string, sessionID uuid.UUID, err error) {
 	claims, err := u.jwtManager.AccessClaims(token)
 	if err != nil {
-		return uuid.Nil, nil, err
+		return uuid.Nil, nil, uuid.Nil, err
 	}
 	roles = claims.Roles
 	userID = claims.UserID
+	sessionID = claims.SessionID
 	err = nil
 	return
 }
@@ -105,7 +191,7 @@ func (u *AuthService) User(ctx context.Context, id uuid.UUID) (*models.User, err
 	return user, nil
 }
 
-func (u *AuthService) LoginUser(ctx context.Context, username, password string) (accessToken, refreshToken string, err error) {
+func (u *AuthService) LoginUser(ctx context.Context, username, password string, info models.SessionInfo) (accessToken, refreshToken string, err error) {
 	user, err := u.authRepo.UserByName(ctx, username)
 	if err != nil {
 		return "", "", err
@@ -114,17 +200,30 @@ func (u *AuthService) LoginUser(ctx context.Context, username, password string)
 	if !checkPasswordHash(password, user.Password) {
 		return "", "", app_errors.ErrIncorrectPassword
 	}
+	if user.Blocked {
+		return "", "", app_errors.ErrUserBlocked
+	}
+	if u.opts.RequireVerifiedEmail && !user.EmailVerified {
+		return "", "", app_errors.ErrEmailNotVerified
+	}
 
-	tokenPair, err := u.jwtManager.GenerateTokenPair(user.ID, user.Roles)
+	sessionID := uuid.New()
+	tokenPair, err := u.jwtManager.GenerateTokenPair(user.ID, sessionID, user.Roles)
 	if err != nil {
 		return "", "", err
 	}
 
-	err = u.tokenRepo.DeleteUserTokens(ctx, user.ID)
-	if err != nil {
-		return "", "", err
+	if err = u.tokenRepo.DeleteExpiredTokens(ctx, user.ID); err != nil {
+		u.log.ErrorErr("failed to delete expired sessions", err)
 	}
-	_, err = u.tokenRepo.Create(ctx, user.ID, tokenPair.RefreshToken)
+	session := models.RefreshToken{
+		FamilyID:   sessionID,
+		UserID:     user.ID,
+		DeviceName: info.DeviceName,
+		UserAgent:  info.UserAgent,
+		IP:         info.IP,
+	}
+	_, err = u.tokenRepo.Create(ctx, session, tokenPair.RefreshToken)
 	if err != nil {
 		return "", "", err
 	}
@@ -135,23 +234,54 @@ func (u *AuthService) LoginUser(ctx context.Context, username, password string)
 func (u *AuthService) CreateUser(ctx context.Context, user models.User) (*models.User, error) {
 	var err error
 
-	if len(user.Password) > 16 || len(user.Password) < 6 {
-		return nil, app_errors.ErrIncorrectPassword
+	if err := validatePassword(user.Password); err != nil {
+		return nil, err
 	}
 
 	user.Password, err = hashPassword(user.Password)
 	if err != nil {
 		return nil, err
 	}
+	// other roles are granted only by admins, see the author application flow
+	user.Roles = []string{models.ClientRole}
 
 	createdUser, err := u.authRepo.CreateUser(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := u.sendEmailVerification(ctx, createdUser); err != nil {
+		u.log.ErrorErr("failed to send verification email", err, "user_id", createdUser.ID.String())
+	}
+
 	return createdUser, nil
 }
 
+func validatePassword(password string) error {
+	if len(password) > 16 || len(password) < 6 {
+		return app_errors.ErrIncorrectPassword
+	}
+	return nil
+}
+
+func (u *AuthService) revokeReusedFamily(ctx context.Context, record *models.RefreshToken, info models.SessionInfo) error {
+	u.reportTokenReuse(record, info)
+	if err := u.tokenRepo.RevokeFamily(ctx, record.FamilyID); err != nil {
+		return err
+	}
+	return app_errors.ErrTokenReused
+}
+
+func (u *AuthService) reportTokenReuse(record *models.RefreshToken, info models.SessionInfo) {
+	u.log.Warn("security: refresh token reuse detected, revoking session",
+		"user_id", record.UserID.String(),
+		"session_id", record.FamilyID.String(),
+		"token_id", record.ID.String(),
+		"ip", info.IP,
+		"user_agent", info.UserAgent,
+	)
+}
+
 func hashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	return string(bytes), err
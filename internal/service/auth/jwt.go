@@ -36,6 +36,7 @@ func NewJWTManager(secretKey, issuer string, accessTTL, refreshTTL time.Duration
 type AccessTokenClaims struct {
 	TokenType string    `json:"token_type"`
 	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"sid"`
 	Roles     []string  `json:"roles"`
 	jwt.RegisteredClaims
 }
@@ -43,6 +44,7 @@ type AccessTokenClaims struct {
 type RefreshTokenClaims struct {
 	TokenType string    `json:"token_type"`
 	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"sid"`
 	jwt.RegisteredClaims
 }
 
@@ -95,7 +97,7 @@ func (j *JWTManager) TokenType(token *jwt.Token, t string) bool {
 	return false
 }
 
-func (j *JWTManager) GenerateTokenPair(userID uuid.UUID, roles []string) (*models.TokenPair, error) {
+func (j *JWTManager) GenerateTokenPair(userID, sessionID uuid.UUID, roles []string) (*models.TokenPair, error) {
 	now := time.Now()
 	accessToken := jwt.NewWithClaims(signingMethod, AccessTokenClaims{
 		TokenType: AccessTokenType,
@@ -105,8 +107,9 @@ func (j *JWTManager) GenerateTokenPair(userID uuid.UUID, roles []string) (*model
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.accessTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
-		UserID: userID,
-		Roles:  roles,
+		UserID:    userID,
+		SessionID: sessionID,
+		Roles:     roles,
 	})
 
 	accessKey := []byte(j.secretKey)
@@ -122,12 +125,14 @@ func (j *JWTManager) GenerateTokenPair(userID uuid.UUID, roles []string) (*model
 	refreshToken := jwt.NewWithClaims(signingMethod, RefreshTokenClaims{
 		TokenType: RefreshTokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   userID.String(),
 			Issuer:    j.issuer,
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.refreshTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 	})
 
 	key := []byte(j.secretKey)
@@ -145,3 +150,43 @@ func (j *JWTManager) GenerateTokenPair(userID uuid.UUID, roles []string) (*model
 		RefreshToken: refreshToken,
 	}, nil
 }
+
+type ActionTokenClaims struct {
+	TokenType string `json:"token_type"`
+	jwt.RegisteredClaims
+}
+
+func (j *JWTManager) GenerateActionToken(userID, tokenID uuid.UUID, purpose string, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(signingMethod, ActionTokenClaims{
+		TokenType: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID.String(),
+			Subject:   userID.String(),
+			Issuer:    j.issuer,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+	signed, err := token.SignedString([]byte(j.secretKey))
+	if err != nil {
+		return "", fmt.Errorf("%s token signing failed: %v", purpose, err)
+	}
+	return signed, nil
+}
+
+func (j *JWTManager) ActionClaims(tokenStr, purpose string) (*ActionTokenClaims, error) {
+	claims := &ActionTokenClaims{}
+	_, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != signingMethod {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(j.secretKey), nil
+	})
+	if err != nil {
+		return nil, app_errors.ErrInvalidActionToken
+	}
+	if claims.TokenType != purpose {
+		return nil, app_errors.ErrInvalidActionToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,158 @@
+package cleanup
+
+import (
+	"SkillForge/internal/models"
+	"SkillForge/pkg/logger"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type courseRepo interface {
+	DeleteCourse(ctx context.Context, id uuid.UUID) error
+}
+
+type lessonRepo interface {
+	CourseMediaContents(ctx context.Context, courseID uuid.UUID) ([]models.CourseContent, error)
+}
+
+type searchRepo interface {
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type logoRepo interface {
+	DeleteLogo(ctx context.Context, objectKey string) error
+}
+
+type mediaStorage interface {
+	DeletePhoto(ctx context.Context, objectKey string) error
+	DeleteVideo(ctx context.Context, objectKey string) error
+	DeleteCourseSubmissions(ctx context.Context, courseID uuid.UUID) error
+}
+
+type failureRepo interface {
+	SaveCleanupFailures(ctx context.Context, failures []models.CleanupFailure) error
+	CleanupFailures(ctx context.Context) ([]models.CleanupFailure, error)
+	DeleteCleanupFailure(ctx context.Context, id uuid.UUID) error
+	RecordCleanupRetry(ctx context.Context, failure *models.CleanupFailure) error
+}
+
+// CourseCleaner removes a course together with everything stored outside Postgres
+type CourseCleaner struct {
+	log          logger.Log
+	courseRepo   courseRepo
+	lessonRepo   lessonRepo
+	searchRepo   searchRepo
+	logoRepo     logoRepo
+	mediaStorage mediaStorage
+	failureRepo  failureRepo
+}
+
+func NewCourseCleaner(log logger.Log, c courseRepo, l lessonRepo, s searchRepo, logo logoRepo, m mediaStorage, f failureRepo) *CourseCleaner {
+	return &CourseCleaner{
+		log:          log,
+		courseRepo:   c,
+		lessonRepo:   l,
+		searchRepo:   s,
+		logoRepo:     logo,
+		mediaStorage: m,
+		failureRepo:  f,
+	}
+}
+
+// DeleteCourse removes the row first, so the course disappears even if storage is unavailable;
+// objects that could not be removed are listed in the report and stored to be retried
+func (c *CourseCleaner) DeleteCourse(ctx context.Context, course *models.Course) (*models.CourseDeletionReport, error) {
+	media, err := c.lessonRepo.CourseMediaContents(ctx, course.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.courseRepo.DeleteCourse(ctx, course.ID); err != nil {
+		return nil, err
+	}
+
+	var targets []models.CleanupFailure
+	if course.LogoObjectKey != "" {
+		targets = append(targets, models.CleanupFailure{Target: models.CleanupTargetLogo, ObjectKey: course.LogoObjectKey})
+	}
+	for _, content := range media {
+		targets = append(targets, models.CleanupFailure{
+			Target:      models.CleanupTargetLessonMedia,
+			ObjectKey:   *content.ObjectKey,
+			ContentType: content.Type,
+		})
+	}
+	targets = append(targets,
+		models.CleanupFailure{Target: models.CleanupTargetSubmissions},
+		models.CleanupFailure{Target: models.CleanupTargetSearchIndex},
+	)
+
+	report := &models.CourseDeletionReport{
+		CourseID: course.ID,
+		Failures: make([]models.CleanupFailure, 0),
+	}
+	for _, target := range targets {
+		target.CourseID = course.ID
+		if err := c.remove(ctx, target); err != nil {
+			c.log.ErrorErr("course cleanup failed", err, "course_id", course.ID.String(), "target", target.Target, "object_key", target.ObjectKey)
+			target.Error = err.Error()
+			report.Failures = append(report.Failures, target)
+		}
+	}
+	if len(report.Failures) > 0 {
+		// the course is already gone, losing the record only leaves orphaned objects behind
+		if err := c.failureRepo.SaveCleanupFailures(ctx, report.Failures); err != nil {
+			c.log.ErrorErr("failed to save cleanup failures", err, "course_id", course.ID.String())
+		}
+	}
+	return report, nil
+}
+
+func (c *CourseCleaner) CleanupFailures(ctx context.Context) ([]models.CleanupFailure, error) {
+	return c.failureRepo.CleanupFailures(ctx)
+}
+
+// RetryCleanup tries every stored failure again; resolved ones are forgotten, the rest keep the latest error
+func (c *CourseCleaner) RetryCleanup(ctx context.Context) (*models.CleanupRetryReport, error) {
+	failures, err := c.failureRepo.CleanupFailures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &models.CleanupRetryReport{
+		Retried:  len(failures),
+		Failures: make([]models.CleanupFailure, 0),
+	}
+	for _, failure := range failures {
+		if err := c.remove(ctx, failure); err != nil {
+			failure.Error = err.Error()
+			if err := c.failureRepo.RecordCleanupRetry(ctx, &failure); err != nil {
+				return nil, err
+			}
+			report.Failures = append(report.Failures, failure)
+			continue
+		}
+		if err := c.failureRepo.DeleteCleanupFailure(ctx, failure.ID); err != nil {
+			return nil, err
+		}
+		report.Resolved++
+	}
+	return report, nil
+}
+
+func (c *CourseCleaner) remove(ctx context.Context, target models.CleanupFailure) error {
+	switch target.Target {
+	case models.CleanupTargetLogo:
+		return c.logoRepo.DeleteLogo(ctx, target.ObjectKey)
+	case models.CleanupTargetLessonMedia:
+		if target.ContentType == models.ContentTypeVideo {
+			return c.mediaStorage.DeleteVideo(ctx, target.ObjectKey)
+		}
+		return c.mediaStorage.DeletePhoto(ctx, target.ObjectKey)
+	case models.CleanupTargetSubmissions:
+		return c.mediaStorage.DeleteCourseSubmissions(ctx, target.CourseID)
+	case models.CleanupTargetSearchIndex:
+		return c.searchRepo.Delete(ctx, target.CourseID)
+	}
+	return fmt.Errorf("unknown cleanup target %q", target.Target)
+}
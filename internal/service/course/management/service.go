@@ -32,30 +32,38 @@ type courseRepo interface {
 	ChangeStatus(ctx context.Context, id uuid.UUID, status string) error
 	ListCoursesByAuthor(ctx context.Context, authorID uuid.UUID) ([]models.Course, error)
 	UpdateCourseLogo(ctx context.Context, courseID uuid.UUID, logoObjectKey string) error
+	UpdateCourseInfo(ctx context.Context, course *models.Course) error
 }
 
 type searchRepo interface {
 	Index(ctx context.Context, course models.Course) error
+	Update(ctx context.Context, course models.Course) error
 	Search(ctx context.Context, query string, size int) ([]uuid.UUID, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Count(ctx context.Context, query string) (int, error)
 }
 
+type courseCleaner interface {
+	DeleteCourse(ctx context.Context, course *models.Course) (*models.CourseDeletionReport, error)
+}
+
 type CourseManagementService struct {
 	log        logger.Log
 	userRepo   userRepo
 	courseRepo courseRepo
 	searchRepo searchRepo
 	logoRepo   logoRepo
+	cleaner    courseCleaner
 }
 
-func NewCourseManagementService(log logger.Log, u userRepo, c courseRepo, s searchRepo, l logoRepo) *CourseManagementService {
+func NewCourseManagementService(log logger.Log, u userRepo, c courseRepo, s searchRepo, l logoRepo, cl courseCleaner) *CourseManagementService {
 	return &CourseManagementService{
 		log:        log,
 		userRepo:   u,
 		courseRepo: c,
 		searchRepo: s,
 		logoRepo:   l,
+		cleaner:    cl,
 	}
 }
 
@@ -99,14 +107,86 @@ func (s *CourseManagementService) Hide(ctx context.Context, id uuid.UUID, author
 	if err := s.courseRepo.ChangeStatus(ctx, id, models.StatusHidden); err != nil {
 		return err
 	}
+
+	if err := s.searchRepo.Delete(ctx, id); err != nil {
+		s.log.ErrorErr("error removing course from index", err)
+		return err
+	}
 	return nil
 }
 
-func (s *CourseManagementService) GetCourseStatus(ctx context.Context, id uuid.UUID) (string, error) {
+func (s *CourseManagementService) UpdateCourse(ctx context.Context, id, authorID uuid.UUID, update models.CourseUpdate) (*models.Course, error) {
+	if update.Title == nil && update.Description == nil && update.DefaultMinScore == nil && update.ScorePolicy == nil {
+		return nil, app_errors.ErrNothingToUpdate
+	}
+	course, err := s.courseRepo.CourseByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if authorID != course.AuthorID {
+		return nil, app_errors.ErrNotCourseAuthor
+	}
+
+	if update.Title != nil {
+		course.Title = strings.TrimSpace(*update.Title)
+		if course.Title == "" {
+			return nil, app_errors.ErrInvalidCourseData
+		}
+	}
+	if update.Description != nil {
+		course.Description = strings.TrimSpace(*update.Description)
+		if course.Description == "" {
+			return nil, app_errors.ErrInvalidCourseData
+		}
+	}
+	if update.DefaultMinScore != nil {
+		if *update.DefaultMinScore < 0 || *update.DefaultMinScore > 100 {
+			return nil, app_errors.ErrInvalidMinScore
+		}
+		course.DefaultMinScore = *update.DefaultMinScore
+	}
+	if update.ScorePolicy != nil {
+		if !models.IsScorePolicy(*update.ScorePolicy) {
+			return nil, app_errors.ErrInvalidScorePolicy
+		}
+		course.ScorePolicy = *update.ScorePolicy
+	}
+	if err := s.courseRepo.UpdateCourseInfo(ctx, course); err != nil {
+		return nil, err
+	}
+
+	if course.Status == models.StatusPublic {
+		if err := s.searchRepo.Update(ctx, *course); err != nil {
+			// the document may be missing if an earlier indexing failed, so write it from scratch
+			s.log.ErrorErr("error updating course in index, reindexing", err)
+			if err := s.searchRepo.Index(ctx, *course); err != nil {
+				s.log.ErrorErr("error indexing course", err)
+				return nil, err
+			}
+		}
+	}
+	return course, nil
+}
+
+func (s *CourseManagementService) DeleteCourse(ctx context.Context, id, authorID uuid.UUID) (*models.CourseDeletionReport, error) {
+	course, err := s.courseRepo.CourseByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if authorID != course.AuthorID {
+		return nil, app_errors.ErrNotCourseAuthor
+	}
+	return s.cleaner.DeleteCourse(ctx, course)
+}
+
+func (s *CourseManagementService) GetCourseStatus(ctx context.Context, id, viewerID uuid.UUID) (string, error) {
 	course, err := s.courseRepo.CourseByID(ctx, id)
 	if err != nil {
 		return "", err
 	}
+	if !course.VisibleTo(viewerID) {
+		return "", app_errors.ErrCourseNotFound
+	}
 	return course.Status, nil
 }
 
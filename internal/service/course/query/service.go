@@ -5,6 +5,7 @@ import (
 	"SkillForge/internal/models"
 	"SkillForge/pkg/logger"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 )
@@ -27,37 +28,47 @@ type logoRepo interface {
 type searchRepo interface {
 	Search(ctx context.Context, query string, size int) ([]uuid.UUID, error)
 	Count(ctx context.Context, query string) (int, error)
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type subRepo interface {
 	GetSubscribedCourses(ctx context.Context, userID uuid.UUID) ([]models.Course, error)
 }
 
+type progressRepo interface {
+	SubscribedCourseCompletion(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]float64, error)
+}
+
 type CourseQueryService struct {
-	log        logger.Log
-	userRepo   userRepo
-	courseRepo courseRepo
-	logoRepo   logoRepo
-	searchRepo searchRepo
-	subRepo    subRepo
+	log          logger.Log
+	userRepo     userRepo
+	courseRepo   courseRepo
+	logoRepo     logoRepo
+	searchRepo   searchRepo
+	subRepo      subRepo
+	progressRepo progressRepo
 }
 
-func NewCourseQueryService(log logger.Log, c courseRepo, l logoRepo, u userRepo, s searchRepo, sub subRepo) *CourseQueryService {
+func NewCourseQueryService(log logger.Log, c courseRepo, l logoRepo, u userRepo, s searchRepo, sub subRepo, p progressRepo) *CourseQueryService {
 	return &CourseQueryService{
-		log:        log,
-		courseRepo: c,
-		logoRepo:   l,
-		userRepo:   u,
-		searchRepo: s,
-		subRepo:    sub,
+		log:          log,
+		courseRepo:   c,
+		logoRepo:     l,
+		userRepo:     u,
+		searchRepo:   s,
+		subRepo:      sub,
+		progressRepo: p,
 	}
 }
 
-func (s *CourseQueryService) CourseByID(ctx context.Context, id uuid.UUID) (*models.CoursePreview, error) {
+func (s *CourseQueryService) CourseByID(ctx context.Context, id, viewerID uuid.UUID) (*models.CoursePreview, error) {
 	course, err := s.courseRepo.CourseByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if !course.VisibleTo(viewerID) {
+		return nil, app_errors.ErrCourseNotFound
+	}
 
 	var logoURL string
 	if course.LogoObjectKey != "" {
@@ -155,10 +166,19 @@ func (s *CourseQueryService) SearchCoursesPreview(ctx context.Context, query str
 	previews := make([]models.CoursePreview, 0, len(ids))
 	for _, id := range ids {
 		course, err := s.courseRepo.CourseByID(ctx, id)
-		if err != nil {
+		if err != nil && !errors.Is(err, app_errors.ErrCourseNotFound) {
 			s.log.ErrorErr("search preview: failed to load course by id", err)
 			continue
 		}
+		// the index may lag behind a status change or a deletion: the stale document is left out of the page
+		// and the total, and removed so later searches do not count it either
+		if err != nil || course.Status != models.StatusPublic {
+			total--
+			if err := s.searchRepo.Delete(ctx, id); err != nil {
+				s.log.ErrorErr("search preview: failed to remove stale course from index", err, "course_id", id.String())
+			}
+			continue
+		}
 
 		desc := course.Description
 		if len(desc) > 200 {
@@ -189,7 +209,7 @@ func (s *CourseQueryService) SearchCoursesPreview(ctx context.Context, query str
 		})
 	}
 
-	return previews, total, nil
+	return previews, max(total, len(previews)), nil
 }
 
 func (s *CourseQueryService) GetCourseLogoURL(ctx context.Context, courseID uuid.UUID) (string, error) {
@@ -252,9 +272,16 @@ func (s *CourseQueryService) GetSubscribedCourses(ctx context.Context, userID uu
 	if err != nil {
 		return nil, err
 	}
+	completion, err := s.progressRepo.SubscribedCourseCompletion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	var previews []models.CoursePreview
 	for _, course := range courses {
+		if !course.VisibleTo(userID) {
+			continue
+		}
 		var logoURL string
 		if course.LogoObjectKey != "" {
 			logoURL, err = s.logoRepo.GetLogoURL(ctx, course.LogoObjectKey)
@@ -277,6 +304,9 @@ func (s *CourseQueryService) GetSubscribedCourses(ctx context.Context, userID uu
 			LogoURL:     logoURL,
 			StarsCount:  course.StarsCount,
 		}
+		// courses without lessons have nothing to complete yet
+		percent := completion[course.ID]
+		preview.CompletionPercent = &percent
 		previews = append(previews, preview)
 	}
 
@@ -35,6 +35,9 @@ func (s *CourseSubscriptionService) Subscribe(ctx context.Context, courseID, use
 	if err != nil {
 		return err
 	}
+	if !course.VisibleTo(userID) {
+		return app_errors.ErrCourseNotFound
+	}
 	if course.Status != models.StatusPublic {
 		return app_errors.ErrCourseNotPublished
 	}
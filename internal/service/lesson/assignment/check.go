@@ -0,0 +1,70 @@
+package assignment
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"fmt"
+)
+
+// checkSubmission enforces what the assignment accepts: text, files or both, and how many files
+func checkSubmission(assignment models.Assignment, text string, files []models.SubmissionUpload) error {
+	if text == "" && len(files) == 0 {
+		return fmt.Errorf("%w: submission is empty", app_errors.ErrInvalidSubmission)
+	}
+	if text != "" && !assignment.AllowText {
+		return fmt.Errorf("%w: the assignment accepts files only", app_errors.ErrInvalidSubmission)
+	}
+	if len(files) > 0 && !assignment.AllowFiles {
+		return fmt.Errorf("%w: the assignment accepts a text answer only", app_errors.ErrInvalidSubmission)
+	}
+	if len(files) > assignment.MaxFiles {
+		return fmt.Errorf("%w: at most %d files can be attached", app_errors.ErrInvalidSubmission, assignment.MaxFiles)
+	}
+	for _, file := range files {
+		if file.Size > maxSubmissionFileSize {
+			return fmt.Errorf("%w: %q is larger than %d MB", app_errors.ErrInvalidSubmission, file.Name, maxSubmissionFileSize>>20)
+		}
+	}
+	return nil
+}
+
+// rubricScores checks that every criterion is scored once within its points and returns the scores in rubric order
+func rubricScores(assignment models.Assignment, scores []models.RubricScore) ([]models.RubricScore, error) {
+	byCriterion := make(map[string]models.RubricScore, len(scores))
+	var fields []app_errors.FieldError
+	for i, score := range scores {
+		if _, ok := byCriterion[score.CriterionID]; ok {
+			fields = append(fields, app_errors.FieldError{Field: fmt.Sprintf("scores[%d].criterion_id", i), Message: "criterion is scored twice"})
+			continue
+		}
+		byCriterion[score.CriterionID] = score
+	}
+
+	ordered := make([]models.RubricScore, 0, len(assignment.Rubric))
+	for _, criterion := range assignment.Rubric {
+		score, ok := byCriterion[criterion.ID]
+		if !ok {
+			fields = append(fields, app_errors.FieldError{Field: "scores", Message: fmt.Sprintf("criterion %q is not scored", criterion.ID)})
+			continue
+		}
+		delete(byCriterion, criterion.ID)
+		if score.Points < 0 || score.Points > criterion.MaxPoints {
+			fields = append(fields, app_errors.FieldError{
+				Field:   "scores",
+				Message: fmt.Sprintf("points for %q must be between 0 and %g", criterion.ID, criterion.MaxPoints),
+			})
+		}
+		ordered = append(ordered, score)
+	}
+	for i, score := range scores {
+		if _, ok := byCriterion[score.CriterionID]; ok {
+			fields = append(fields, app_errors.FieldError{Field: fmt.Sprintf("scores[%d].criterion_id", i), Message: "unknown criterion"})
+			delete(byCriterion, score.CriterionID)
+		}
+	}
+
+	if len(fields) > 0 {
+		return nil, &app_errors.ValidationError{Err: app_errors.ErrInvalidGrade, Fields: fields}
+	}
+	return ordered, nil
+}
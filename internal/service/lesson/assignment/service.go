@@ -0,0 +1,279 @@
+package assignment
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"SkillForge/pkg/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"strings"
+	"time"
+)
+
+const maxSubmissionFileSize = 50 << 20
+
+type lessonRepo interface {
+	GetLessonByID(ctx context.Context, lessonID uuid.UUID) (models.Lesson, error)
+	GetContentByID(ctx context.Context, contentID uuid.UUID) (models.CourseContent, error)
+}
+
+type courseRepo interface {
+	CourseByID(ctx context.Context, id uuid.UUID) (*models.Course, error)
+}
+
+type subscriptionRepo interface {
+	IsSubscribed(ctx context.Context, courseID, userID uuid.UUID) (bool, error)
+}
+
+type submissionRepo interface {
+	CreateSubmission(ctx context.Context, submission *models.AssignmentSubmission) error
+	SubmissionByID(ctx context.Context, id uuid.UUID) (*models.AssignmentSubmission, error)
+	GradeSubmission(ctx context.Context, submission *models.AssignmentSubmission) error
+	UserSubmissions(ctx context.Context, contentID, userID uuid.UUID) ([]models.AssignmentSubmission, error)
+	CourseSubmissions(ctx context.Context, courseID uuid.UUID, status string, limit, offset int) ([]models.AssignmentSubmission, error)
+}
+
+type lessonProgress interface {
+	RecalculateLesson(ctx context.Context, lessonID, userID uuid.UUID) (models.LessonProgress, error)
+}
+
+type fileStorage interface {
+	UploadSubmission(ctx context.Context, courseID, contentID, userID uuid.UUID, filename string, reader io.Reader, size int64, contentType string) (objectKey string, err error)
+	GetSubmissionURL(ctx context.Context, objectKey string) (string, error)
+	DeleteSubmission(ctx context.Context, objectKey string) error
+}
+
+type AssignmentService struct {
+	log            logger.Log
+	lessonRepo     lessonRepo
+	courseRepo     courseRepo
+	subRepo        subscriptionRepo
+	submissionRepo submissionRepo
+	fileStorage    fileStorage
+	progress       lessonProgress
+}
+
+func NewAssignmentService(log logger.Log, l lessonRepo, c courseRepo, sub subscriptionRepo, s submissionRepo, f fileStorage, p lessonProgress) *AssignmentService {
+	return &AssignmentService{
+		log:            log,
+		lessonRepo:     l,
+		courseRepo:     c,
+		subRepo:        sub,
+		submissionRepo: s,
+		fileStorage:    f,
+		progress:       p,
+	}
+}
+
+// SubmitAssignment sends the learner's work for grading; a new submission is accepted only
+// after the previous one has been graded
+func (s *AssignmentService) SubmitAssignment(ctx context.Context, lessonID, contentID, userID uuid.UUID, text string, files []models.SubmissionUpload) (*models.AssignmentSubmission, error) {
+	assignment, err := s.lessonAssignment(ctx, lessonID, contentID)
+	if err != nil {
+		return nil, err
+	}
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	course, err := s.courseRepo.CourseByID(ctx, lesson.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	// subscribers keep their subscription when a course is hidden, but cannot hand in work to it
+	if !course.VisibleTo(userID) {
+		return nil, app_errors.ErrCourseNotFound
+	}
+	subscribed, err := s.subRepo.IsSubscribed(ctx, course.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !subscribed {
+		return nil, app_errors.ErrLessonAccessDenied
+	}
+
+	text = strings.TrimSpace(text)
+	if err := checkSubmission(assignment, text, files); err != nil {
+		return nil, err
+	}
+	previous, err := s.submissionRepo.UserSubmissions(ctx, contentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, submission := range previous {
+		if submission.Status == models.SubmissionStatusSubmitted {
+			return nil, app_errors.ErrSubmissionPending
+		}
+	}
+
+	submission := &models.AssignmentSubmission{
+		ContentID:   contentID,
+		LessonID:    lessonID,
+		UserID:      userID,
+		Text:        text,
+		Files:       make([]models.SubmissionFile, 0, len(files)),
+		Status:      models.SubmissionStatusSubmitted,
+		Scores:      []models.RubricScore{},
+		MaxPoints:   assignment.MaxPoints(),
+		MinScore:    passThreshold(assignment, course),
+		SubmittedAt: time.Now().UTC(),
+	}
+	for _, file := range files {
+		objectKey, err := s.fileStorage.UploadSubmission(ctx, course.ID, contentID, userID, file.Name, file.Reader, file.Size, file.ContentType)
+		if err != nil {
+			s.deleteFiles(ctx, submission.Files)
+			return nil, err
+		}
+		submission.Files = append(submission.Files, models.SubmissionFile{
+			Name:        file.Name,
+			ObjectKey:   objectKey,
+			Size:        file.Size,
+			ContentType: file.ContentType,
+		})
+	}
+	if err := s.submissionRepo.CreateSubmission(ctx, submission); err != nil {
+		s.deleteFiles(ctx, submission.Files)
+		return nil, err
+	}
+	s.fileURLs(ctx, submission)
+	return submission, nil
+}
+
+func (s *AssignmentService) MySubmissions(ctx context.Context, lessonID, contentID, userID uuid.UUID) ([]models.AssignmentSubmission, error) {
+	if _, err := s.lessonAssignment(ctx, lessonID, contentID); err != nil {
+		return nil, err
+	}
+	submissions, err := s.submissionRepo.UserSubmissions(ctx, contentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range submissions {
+		s.fileURLs(ctx, &submissions[i])
+	}
+	return submissions, nil
+}
+
+// GradingQueue lists the course submissions for its author, oldest first; an empty status lists graded ones too
+func (s *AssignmentService) GradingQueue(ctx context.Context, courseID, authorID uuid.UUID, status string, limit, offset int) ([]models.AssignmentSubmission, error) {
+	course, err := s.courseRepo.CourseByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course.AuthorID != authorID {
+		return nil, app_errors.ErrNotCourseAuthor
+	}
+	submissions, err := s.submissionRepo.CourseSubmissions(ctx, courseID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	for i := range submissions {
+		s.fileURLs(ctx, &submissions[i])
+	}
+	return submissions, nil
+}
+
+// GradeSubmission scores a submission against the current rubric; the grade becomes the result of the assignment block
+// in the lesson progress
+func (s *AssignmentService) GradeSubmission(ctx context.Context, courseID, submissionID, authorID uuid.UUID, scores []models.RubricScore, feedback string) (*models.AssignmentSubmission, error) {
+	submission, err := s.submissionRepo.SubmissionByID(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, submission.LessonID)
+	if err != nil {
+		return nil, err
+	}
+	if lesson.CourseID != courseID {
+		return nil, app_errors.ErrSubmissionNotFound
+	}
+	course, err := s.courseRepo.CourseByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course.AuthorID != authorID {
+		return nil, app_errors.ErrNotCourseAuthor
+	}
+	if submission.Status == models.SubmissionStatusGraded {
+		return nil, app_errors.ErrSubmissionGraded
+	}
+	assignment, err := s.lessonAssignment(ctx, submission.LessonID, submission.ContentID)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, err := rubricScores(assignment, scores)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	submission.Status = models.SubmissionStatusGraded
+	submission.Scores = ordered
+	submission.Points = 0
+	for _, score := range ordered {
+		submission.Points += score.Points
+	}
+	submission.MaxPoints = assignment.MaxPoints()
+	submission.Score = submission.Points / submission.MaxPoints * 100
+	submission.MinScore = passThreshold(assignment, course)
+	submission.Result = models.LessonStatusFailed
+	if submission.Score >= submission.MinScore {
+		submission.Result = models.LessonStatusPassed
+	}
+	submission.Feedback = strings.TrimSpace(feedback)
+	submission.GradedBy = &authorID
+	submission.GradedAt = &now
+	if err := s.submissionRepo.GradeSubmission(ctx, submission); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.progress.RecalculateLesson(ctx, submission.LessonID, submission.UserID); err != nil {
+		return nil, err
+	}
+	s.fileURLs(ctx, submission)
+	return submission, nil
+}
+
+func (s *AssignmentService) lessonAssignment(ctx context.Context, lessonID, contentID uuid.UUID) (models.Assignment, error) {
+	content, err := s.lessonRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		return models.Assignment{}, err
+	}
+	if content.LessonID != lessonID || content.Type != models.ContentTypeAssignment || content.AssignmentJSON == nil {
+		return models.Assignment{}, app_errors.ErrAssignmentNotFound
+	}
+	var assignment models.Assignment
+	if err := json.Unmarshal([]byte(*content.AssignmentJSON), &assignment); err != nil {
+		return models.Assignment{}, fmt.Errorf("invalid assignment format: %w", err)
+	}
+	return assignment, nil
+}
+
+func (s *AssignmentService) fileURLs(ctx context.Context, submission *models.AssignmentSubmission) {
+	for i := range submission.Files {
+		url, err := s.fileStorage.GetSubmissionURL(ctx, submission.Files[i].ObjectKey)
+		if err != nil {
+			s.log.ErrorErr("failed to presign submission file", err, "submission_id", submission.ID.String())
+			continue
+		}
+		submission.Files[i].URL = url
+	}
+}
+
+func (s *AssignmentService) deleteFiles(ctx context.Context, files []models.SubmissionFile) {
+	for _, file := range files {
+		if err := s.fileStorage.DeleteSubmission(ctx, file.ObjectKey); err != nil {
+			s.log.ErrorErr("failed to delete submission file from minio", err, "object_key", file.ObjectKey)
+		}
+	}
+}
+
+// passThreshold takes the assignment's own minScore and falls back to the course default when it is not set
+func passThreshold(assignment models.Assignment, course *models.Course) float64 {
+	if assignment.MinScore != nil {
+		return *assignment.MinScore
+	}
+	return course.DefaultMinScore
+}
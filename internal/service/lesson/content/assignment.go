@@ -0,0 +1,75 @@
+package content
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// prepareAssignment validates an assignment sent by the editor and gives every rubric criterion a stable id,
+// grades refer to criteria by these ids
+func prepareAssignment(assignmentJSON *string) (*string, error) {
+	if assignmentJSON == nil {
+		return nil, assignmentError(app_errors.FieldError{Field: "assignment_json", Message: "is required"})
+	}
+	var assignment models.Assignment
+	if err := json.Unmarshal([]byte(*assignmentJSON), &assignment); err != nil {
+		return nil, assignmentError(app_errors.FieldError{Field: "assignment_json", Message: err.Error()})
+	}
+	if assignment.AllowFiles && assignment.MaxFiles == 0 {
+		assignment.MaxFiles = models.DefaultAssignmentMaxFiles
+	}
+
+	if fields := validateAssignment(assignment); len(fields) > 0 {
+		return nil, assignmentError(fields...)
+	}
+
+	for i := range assignment.Rubric {
+		if assignment.Rubric[i].ID == "" {
+			assignment.Rubric[i].ID = uuid.NewString()
+		}
+	}
+	data, err := json.Marshal(assignment)
+	if err != nil {
+		return nil, err
+	}
+	result := string(data)
+	return &result, nil
+}
+
+func validateAssignment(assignment models.Assignment) []app_errors.FieldError {
+	var f fieldErrors
+	if assignment.Instructions == "" {
+		f.add("instructions", "must not be empty")
+	}
+	if !assignment.AllowText && !assignment.AllowFiles {
+		f.add("allowText", "allow a text answer, files or both")
+	}
+	if assignment.MaxFiles < 0 {
+		f.add("maxFiles", "must not be negative")
+	}
+	if assignment.MinScore != nil && (*assignment.MinScore < 0 || *assignment.MinScore > 100) {
+		f.add("minScore", "must be between 0 and 100")
+	}
+	if len(assignment.Rubric) == 0 {
+		f.add("rubric", "assignment must have at least one criterion")
+	}
+	ids := make([]string, 0, len(assignment.Rubric))
+	for i, criterion := range assignment.Rubric {
+		ids = append(ids, criterion.ID)
+		if criterion.Title == "" {
+			f.add(fmt.Sprintf("rubric[%d].title", i), "must not be empty")
+		}
+		if criterion.MaxPoints <= 0 {
+			f.add(fmt.Sprintf("rubric[%d].maxPoints", i), "must be positive")
+		}
+	}
+	f.unique(ids, func(i int) string { return fmt.Sprintf("rubric[%d].id", i) })
+	return f
+}
+
+func assignmentError(fields ...app_errors.FieldError) error {
+	return &app_errors.ValidationError{Err: app_errors.ErrInvalidAssignment, Fields: fields}
+}
@@ -0,0 +1,122 @@
+package content
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"SkillForge/internal/service/lesson/quizimport"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+const maxQuizImportSize = 10 << 20
+
+// PreviewQuizImport converts the file without saving it, so the author can review the questions,
+// the items that were skipped and the validation errors that would block the import
+func (s *LessonContentService) PreviewQuizImport(ctx context.Context, lessonID, authorID uuid.UUID, format, filename, title string, file io.Reader, size int64) (*models.QuizImport, error) {
+	if _, err := s.authorLesson(ctx, lessonID, authorID); err != nil {
+		return nil, err
+	}
+	result, _, err := convertQuiz(format, filename, title, file, size)
+	return result, err
+}
+
+// ImportQuiz converts the file and adds it to the lesson as a quiz block; skipped items are left out,
+// any validation error rejects the whole import
+func (s *LessonContentService) ImportQuiz(ctx context.Context, lessonID, authorID uuid.UUID, format, filename, title string, file io.Reader, size int64, position int) (*models.QuizImport, error) {
+	if _, err := s.authorLesson(ctx, lessonID, authorID); err != nil {
+		return nil, err
+	}
+	result, quizJSON, err := convertQuiz(format, filename, title, file, size)
+	if err != nil {
+		return nil, err
+	}
+	if result.Imported == 0 {
+		return nil, app_errors.ErrNothingImported
+	}
+	if !result.Valid {
+		var fields []app_errors.FieldError
+		for _, issue := range result.Issues {
+			if issue.Field != "" {
+				fields = append(fields, app_errors.FieldError{Field: issue.Field, Message: issue.Message})
+			}
+		}
+		return nil, quizError(fields...)
+	}
+
+	content, err := s.saveContent(ctx, models.CourseContent{
+		LessonID: lessonID,
+		Type:     models.ContentTypeQuiz,
+		Order:    position,
+		QuizJSON: quizJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Content = content
+	return result, nil
+}
+
+// convertQuiz parses the file and runs the quiz through the same checks as the editor; the stored form
+// is returned only when the quiz is valid
+func convertQuiz(format, filename, title string, file io.Reader, size int64) (*models.QuizImport, *string, error) {
+	if size > maxQuizImportSize {
+		return nil, nil, app_errors.ErrFileSize
+	}
+	data, err := io.ReadAll(io.LimitReader(file, maxQuizImportSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) > maxQuizImportSize {
+		return nil, nil, app_errors.ErrFileSize
+	}
+	if format == "" {
+		format = quizimport.Detect(filename, data)
+	}
+	quiz, issues, err := quizimport.Parse(format, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	quiz.Title = strings.TrimSpace(title)
+	if quiz.Title == "" {
+		quiz.Title = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+
+	result := &models.QuizImport{
+		Format:   format,
+		Quiz:     quiz,
+		Imported: len(quiz.Questions),
+		Issues:   issues,
+	}
+	for _, issue := range issues {
+		if issue.Skipped {
+			result.Skipped++
+		}
+	}
+
+	raw, err := json.Marshal(quiz)
+	if err != nil {
+		return nil, nil, err
+	}
+	quizJSON := string(raw)
+	stored, err := prepareQuiz(&quizJSON)
+	var validationErr *app_errors.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		for _, field := range validationErr.Fields {
+			result.Issues = append(result.Issues, models.ImportIssue{Field: field.Field, Message: field.Message})
+		}
+		return result, nil, nil
+	case err != nil:
+		return nil, nil, err
+	}
+	if err := json.Unmarshal([]byte(*stored), &result.Quiz); err != nil {
+		return nil, nil, err
+	}
+	result.Valid = true
+	return result, stored, nil
+}
@@ -0,0 +1,293 @@
+package content
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+)
+
+// legacy type names written by the first version of the quiz editor
+var questionTypeAliases = map[string]string{
+	"single_choice":   models.QuestionTypeSingle,
+	"multiple_choice": models.QuestionTypeMultiple,
+}
+
+// prepareQuiz validates a quiz sent by the editor and returns it in the stored form:
+// current schema version, canonical question types and stable ids for every question and option.
+// Answers are graded by these ids, never by position.
+func prepareQuiz(quizJSON *string) (*string, error) {
+	if quizJSON == nil {
+		return nil, quizError(app_errors.FieldError{Field: "quiz_json", Message: "is required"})
+	}
+	var quiz models.QuizJSON
+	if err := json.Unmarshal([]byte(*quizJSON), &quiz); err != nil {
+		return nil, quizError(app_errors.FieldError{Field: "quiz_json", Message: err.Error()})
+	}
+	if quiz.Version == 0 {
+		quiz.Version = models.QuizSchemaVersion
+	}
+	if quiz.Timed() && quiz.LateSubmission == "" {
+		quiz.LateSubmission = models.LateSubmissionReject
+	}
+	for i := range quiz.Questions {
+		if canonical, ok := questionTypeAliases[quiz.Questions[i].Type]; ok {
+			quiz.Questions[i].Type = canonical
+		}
+	}
+
+	if fields := validateQuiz(quiz); len(fields) > 0 {
+		return nil, quizError(fields...)
+	}
+
+	for i := range quiz.Questions {
+		if quiz.Questions[i].ID == "" {
+			quiz.Questions[i].ID = uuid.NewString()
+		}
+		for j := range quiz.Questions[i].Options {
+			if quiz.Questions[i].Options[j].ID == "" {
+				quiz.Questions[i].Options[j].ID = uuid.NewString()
+			}
+		}
+		for j := range quiz.Questions[i].Pairs {
+			pair := &quiz.Questions[i].Pairs[j]
+			if pair.ID == "" {
+				pair.ID = uuid.NewString()
+			}
+			if pair.MatchID == "" {
+				pair.MatchID = uuid.NewString()
+			}
+		}
+	}
+	data, err := json.Marshal(quiz)
+	if err != nil {
+		return nil, err
+	}
+	result := string(data)
+	return &result, nil
+}
+
+type fieldErrors []app_errors.FieldError
+
+func (f *fieldErrors) add(field, format string, args ...any) {
+	*f = append(*f, app_errors.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// unique reports ids repeated within one list, empty ids are filled in later and skipped here
+func (f *fieldErrors) unique(ids []string, path func(i int) string) {
+	seen := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if id == "" {
+			continue
+		}
+		if first, ok := seen[id]; ok {
+			f.add(path(i), "duplicates the id of %s", path(first))
+			continue
+		}
+		seen[id] = i
+	}
+}
+
+var clozeBlankRe = regexp.MustCompile(`\{\{\s*([\w-]+)\s*\}\}`)
+
+func validateQuiz(quiz models.QuizJSON) []app_errors.FieldError {
+	var f fieldErrors
+	if quiz.Version != models.QuizSchemaVersion {
+		f.add("version", "unsupported schema version %d, expected %d", quiz.Version, models.QuizSchemaVersion)
+	}
+	if quiz.MinScore != nil && (*quiz.MinScore < 0 || *quiz.MinScore > 100) {
+		f.add("minScore", "must be between 0 and 100")
+	}
+	if len(quiz.Questions) == 0 {
+		f.add("questions", "quiz must have at least one question")
+	}
+	if quiz.MaxAttempts < 0 {
+		f.add("maxAttempts", "must not be negative")
+	}
+	if quiz.CooldownSeconds < 0 {
+		f.add("cooldownSeconds", "must not be negative")
+	}
+	if quiz.TimeLimitSeconds < 0 {
+		f.add("timeLimitSeconds", "must not be negative")
+	}
+	switch quiz.RevealAnswers {
+	case "", models.RevealNever, models.RevealAfterSubmit, models.RevealAfterPassing, models.RevealAfterLastAttempt:
+	default:
+		f.add("revealAnswers", "must be one of %s, %s, %s, %s",
+			models.RevealNever, models.RevealAfterSubmit, models.RevealAfterPassing, models.RevealAfterLastAttempt)
+	}
+	if quiz.DrawCount < 0 || quiz.DrawCount > len(quiz.Questions) {
+		f.add("drawCount", "must be between 0 and the number of questions (%d)", len(quiz.Questions))
+	}
+	switch quiz.LateSubmission {
+	case "", models.LateSubmissionReject, models.LateSubmissionGrade:
+	default:
+		f.add("lateSubmission", "must be %q or %q", models.LateSubmissionReject, models.LateSubmissionGrade)
+	}
+
+	questionIDs := make([]string, 0, len(quiz.Questions))
+	for i, question := range quiz.Questions {
+		questionIDs = append(questionIDs, question.ID)
+		f.validateQuestion(fmt.Sprintf("questions[%d]", i), question)
+	}
+	f.unique(questionIDs, func(i int) string { return fmt.Sprintf("questions[%d].id", i) })
+	return f
+}
+
+func (f *fieldErrors) validateQuestion(path string, question models.QuizQuestion) {
+	if question.Text == "" {
+		f.add(path+".text", "must not be empty")
+	}
+	if question.Points < 0 {
+		f.add(path+".points", "must not be negative")
+	}
+	switch question.Scoring {
+	case "", models.ScoringAllOrNothing:
+	case models.ScoringProportional:
+		switch question.Type {
+		case models.QuestionTypeMultiple, models.QuestionTypeOrdering, models.QuestionTypeMatching, models.QuestionTypeCloze:
+		default:
+			f.add(path+".scoring", "partial credit does not apply to %s questions", question.Type)
+		}
+	case models.ScoringRightMinusWrong:
+		if question.Type != models.QuestionTypeMultiple {
+			f.add(path+".scoring", "right minus wrong applies only to multiple choice questions")
+		}
+	default:
+		f.add(path+".scoring", "unknown scoring mode %q", question.Scoring)
+	}
+
+	switch question.Type {
+	case models.QuestionTypeSingle:
+		if f.validateOptions(path, question.Options, 2) {
+			if correctOptions(question.Options) != 1 {
+				f.add(path+".options", "exactly one option must be marked correct")
+			}
+		}
+	case models.QuestionTypeMultiple:
+		if f.validateOptions(path, question.Options, 2) {
+			if correctOptions(question.Options) == 0 {
+				f.add(path+".options", "at least one option must be marked correct")
+			}
+		}
+	case models.QuestionTypeOrdering:
+		f.validateOptions(path, question.Options, 2)
+	case models.QuestionTypeText:
+		if question.CorrectAnswer == "" && len(question.AcceptedAnswers) == 0 && question.Pattern == "" {
+			f.add(path+".correctAnswer", "set correctAnswer, acceptedAnswers or pattern")
+		}
+		f.validatePattern(path+".pattern", question.Pattern)
+	case models.QuestionTypeNumeric:
+		if question.NumericAnswer == nil {
+			f.add(path+".numericAnswer", "is required")
+		}
+		if question.Tolerance < 0 {
+			f.add(path+".tolerance", "must not be negative")
+		}
+	case models.QuestionTypeMatching:
+		f.validatePairs(path, question.Pairs)
+	case models.QuestionTypeCloze:
+		f.validateBlanks(path, question)
+	case "":
+		f.add(path+".type", "is required")
+	default:
+		f.add(path+".type", "unknown question type %q", question.Type)
+	}
+}
+
+func correctOptions(options []models.QuizOption) int {
+	correct := 0
+	for _, option := range options {
+		if option.IsCorrect {
+			correct++
+		}
+	}
+	return correct
+}
+
+// validateOptions reports whether the question has enough options to check them further
+func (f *fieldErrors) validateOptions(path string, options []models.QuizOption, minCount int) bool {
+	if len(options) < minCount {
+		f.add(path+".options", "at least %d options are required", minCount)
+		return false
+	}
+	ids := make([]string, 0, len(options))
+	for j, option := range options {
+		ids = append(ids, option.ID)
+		if option.Text == "" {
+			f.add(fmt.Sprintf("%s.options[%d].text", path, j), "must not be empty")
+		}
+	}
+	f.unique(ids, func(j int) string { return fmt.Sprintf("%s.options[%d].id", path, j) })
+	return true
+}
+
+func (f *fieldErrors) validatePairs(path string, pairs []models.QuizPair) {
+	if len(pairs) < 2 {
+		f.add(path+".pairs", "at least 2 pairs are required")
+		return
+	}
+	// prompts and matches are shown to the learner side by side, so their ids must not collide either
+	ids := make([]string, 0, 2*len(pairs))
+	for j, pair := range pairs {
+		ids = append(ids, pair.ID, pair.MatchID)
+		if pair.Prompt == "" {
+			f.add(fmt.Sprintf("%s.pairs[%d].prompt", path, j), "must not be empty")
+		}
+		if pair.Match == "" {
+			f.add(fmt.Sprintf("%s.pairs[%d].match", path, j), "must not be empty")
+		}
+	}
+	f.unique(ids, func(k int) string {
+		if k%2 == 0 {
+			return fmt.Sprintf("%s.pairs[%d].id", path, k/2)
+		}
+		return fmt.Sprintf("%s.pairs[%d].matchId", path, k/2)
+	})
+}
+
+func (f *fieldErrors) validateBlanks(path string, question models.QuizQuestion) {
+	if len(question.Blanks) == 0 {
+		f.add(path+".blanks", "cloze question must have blanks")
+		return
+	}
+	inText := make(map[string]bool)
+	for _, m := range clozeBlankRe.FindAllStringSubmatch(question.Text, -1) {
+		inText[m[1]] = true
+	}
+	ids := make([]string, 0, len(question.Blanks))
+	for j, blank := range question.Blanks {
+		blankPath := fmt.Sprintf("%s.blanks[%d]", path, j)
+		ids = append(ids, blank.ID)
+		switch {
+		case blank.ID == "":
+			f.add(blankPath+".id", "is required and must be referenced in the text as {{id}}")
+		case !inText[blank.ID]:
+			f.add(blankPath+".id", "{{%s}} is missing from the question text", blank.ID)
+		}
+		delete(inText, blank.ID)
+		if len(blank.AcceptedAnswers) == 0 && blank.Pattern == "" {
+			f.add(blankPath+".acceptedAnswers", "set acceptedAnswers or pattern")
+		}
+		f.validatePattern(blankPath+".pattern", blank.Pattern)
+	}
+	f.unique(ids, func(j int) string { return fmt.Sprintf("%s.blanks[%d].id", path, j) })
+	for id := range inText {
+		f.add(path+".text", "{{%s}} has no matching blank", id)
+	}
+}
+
+func (f *fieldErrors) validatePattern(path, pattern string) {
+	if pattern == "" {
+		return
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		f.add(path, "invalid regular expression: %s", err.Error())
+	}
+}
+
+func quizError(fields ...app_errors.FieldError) error {
+	return &app_errors.ValidationError{Err: app_errors.ErrInvalidQuiz, Fields: fields}
+}
@@ -5,9 +5,12 @@ import (
 	"SkillForge/internal/models"
 	"SkillForge/pkg/logger"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"io"
+	"slices"
+	"strings"
 )
 
 type courseRepo interface {
@@ -17,8 +20,18 @@ type courseRepo interface {
 type lessonRepo interface {
 	GetLessonDetail(ctx context.Context, lessonID uuid.UUID) (models.LessonDetail, error)
 	GetLessonByID(ctx context.Context, lessonID uuid.UUID) (models.Lesson, error)
-	UpsertContent(ctx context.Context, content models.CourseContent) (*models.CourseContent, error)
+	CreateContent(ctx context.Context, content models.CourseContent) (*models.CourseContent, error)
+	InsertContent(ctx context.Context, content models.CourseContent) (*models.CourseContent, error)
+	GetContentByID(ctx context.Context, contentID uuid.UUID) (models.CourseContent, error)
+	UpdateContent(ctx context.Context, content models.CourseContent) (*models.CourseContent, error)
+	DeleteContentAndUpdateOrder(ctx context.Context, contentID, lessonID uuid.UUID, order int) error
+	ReorderContents(ctx context.Context, lessonID uuid.UUID, contentIDs []uuid.UUID) error
 	CourseContent(ctx context.Context, courseID uuid.UUID) ([]models.Contents, error)
+	MarkLessonCompleted(ctx context.Context, lessonID, userID uuid.UUID) error
+}
+
+type subscriptionRepo interface {
+	IsSubscribed(ctx context.Context, courseID, userID uuid.UUID) (bool, error)
 }
 
 type mediaStorage interface {
@@ -26,6 +39,9 @@ type mediaStorage interface {
 	UploadVideo(ctx context.Context, courseID uuid.UUID, filename string, reader io.Reader, size int64, contentType string) (objectKey string, err error)
 	GetPhotoURL(ctx context.Context, objectKey string) (string, error)
 	GetVideoURL(ctx context.Context, objectKey string) (string, error)
+	DeletePhoto(ctx context.Context, objectKey string) error
+	DeleteVideo(ctx context.Context, objectKey string) error
+	DeleteAssignmentSubmissions(ctx context.Context, courseID, contentID uuid.UUID) error
 }
 
 type LessonContentService struct {
@@ -33,23 +49,40 @@ type LessonContentService struct {
 	lessonRepo   lessonRepo
 	mediaStorage mediaStorage
 	courseRepo   courseRepo
+	subRepo      subscriptionRepo
 }
 
-func NewLessonContentService(log logger.Log, l lessonRepo, m mediaStorage, c courseRepo) *LessonContentService {
+func NewLessonContentService(log logger.Log, l lessonRepo, m mediaStorage, c courseRepo, sub subscriptionRepo) *LessonContentService {
 	return &LessonContentService{
 		log:          log,
 		lessonRepo:   l,
 		mediaStorage: m,
 		courseRepo:   c,
+		subRepo:      sub,
 	}
 }
 
-func (s *LessonContentService) GetLessonDetail(ctx context.Context, lessonID uuid.UUID) (models.LessonDetail, error) {
+func (s *LessonContentService) GetLessonDetail(ctx context.Context, lessonID, viewerID uuid.UUID, viewerRoles []string) (models.LessonDetail, error) {
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return models.LessonDetail{}, err
+	}
+	fullAccess, err := s.checkLessonAccess(ctx, lesson, viewerID, viewerRoles)
+	if err != nil {
+		return models.LessonDetail{}, err
+	}
+
 	detail, err := s.lessonRepo.GetLessonDetail(ctx, lessonID)
 	if err != nil {
 		return detail, err
 	}
+	if !fullAccess && viewerID != uuid.Nil {
+		s.markViewed(ctx, detail, viewerID)
+	}
 	for i := range detail.Contents {
+		if detail.Contents[i].Type == models.ContentTypeQuiz && !fullAccess {
+			detail.Contents[i].QuizJSON = s.learnerQuiz(detail.Contents[i])
+		}
 		if detail.Contents[i].ObjectKey != nil {
 			switch detail.Contents[i].Type {
 			case models.ContentTypeImage:
@@ -69,32 +102,35 @@ func (s *LessonContentService) GetLessonDetail(ctx context.Context, lessonID uui
 }
 
 func (s *LessonContentService) CreateContent(ctx context.Context, content models.CourseContent, authorID uuid.UUID) (*models.CourseContent, error) {
-	lesson, err := s.lessonRepo.GetLessonByID(ctx, content.LessonID)
-	if err != nil {
+	if _, err := s.authorLesson(ctx, content.LessonID, authorID); err != nil {
 		return nil, err
 	}
-	course, err := s.courseRepo.CourseByID(ctx, lesson.CourseID)
-	if err != nil {
-		return nil, err
-	}
-	if course.AuthorID != authorID {
-		return nil, app_errors.ErrNotCourseAuthor
+	switch content.Type {
+	case models.ContentTypeText:
+		if content.Text == nil || strings.TrimSpace(*content.Text) == "" {
+			return nil, app_errors.ErrEmptyTextContent
+		}
+	case models.ContentTypeQuiz:
+		quizJSON, err := prepareQuiz(content.QuizJSON)
+		if err != nil {
+			return nil, err
+		}
+		content.QuizJSON = quizJSON
+	case models.ContentTypeAssignment:
+		assignmentJSON, err := prepareAssignment(content.AssignmentJSON)
+		if err != nil {
+			return nil, err
+		}
+		content.AssignmentJSON = assignmentJSON
 	}
-	return s.lessonRepo.UpsertContent(ctx, content)
+	return s.saveContent(ctx, content)
 }
 
-func (s *LessonContentService) CreateMediaContent(ctx context.Context, lessonID uuid.UUID, mediaType, filename string, file io.Reader, size int64, contentType string, authorID uuid.UUID) (*models.CourseContent, error) {
-	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+func (s *LessonContentService) CreateMediaContent(ctx context.Context, lessonID uuid.UUID, mediaType, filename string, file io.Reader, size int64, contentType string, position int, authorID uuid.UUID) (*models.CourseContent, error) {
+	lesson, err := s.authorLesson(ctx, lessonID, authorID)
 	if err != nil {
 		return nil, err
 	}
-	course, err := s.courseRepo.CourseByID(ctx, lesson.CourseID)
-	if err != nil {
-		return nil, err
-	}
-	if course.AuthorID != authorID {
-		return nil, app_errors.ErrNotCourseAuthor
-	}
 
 	var objectKey string
 	switch mediaType {
@@ -112,15 +148,208 @@ func (s *LessonContentService) CreateMediaContent(ctx context.Context, lessonID
 	content := models.CourseContent{
 		LessonID:  lessonID,
 		Type:      mediaType,
+		Order:     position,
 		ObjectKey: &objectKey,
 	}
-	return s.lessonRepo.UpsertContent(ctx, content)
+	created, err := s.saveContent(ctx, content)
+	if err != nil {
+		s.deleteMedia(ctx, content)
+		return nil, err
+	}
+	return created, nil
 }
 
-func (s *LessonContentService) CourseContent(ctx context.Context, courseID uuid.UUID) ([]models.Contents, error) {
-	_, err := s.courseRepo.CourseByID(ctx, courseID)
+func (s *LessonContentService) UpdateContent(ctx context.Context, content models.CourseContent, authorID uuid.UUID) (*models.CourseContent, error) {
+	existing, err := s.lessonRepo.GetContentByID(ctx, content.ID)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := s.authorLesson(ctx, existing.LessonID, authorID); err != nil {
+		return nil, err
+	}
+	if content.Type != "" && content.Type != existing.Type {
+		return nil, app_errors.ErrContentTypeMismatch
+	}
+
+	switch existing.Type {
+	case models.ContentTypeText:
+		if content.Text == nil || strings.TrimSpace(*content.Text) == "" {
+			return nil, app_errors.ErrEmptyTextContent
+		}
+		existing.Text = content.Text
+	case models.ContentTypeQuiz:
+		quizJSON, err := prepareQuiz(content.QuizJSON)
+		if err != nil {
+			return nil, err
+		}
+		existing.QuizJSON = quizJSON
+	case models.ContentTypeAssignment:
+		assignmentJSON, err := prepareAssignment(content.AssignmentJSON)
+		if err != nil {
+			return nil, err
+		}
+		existing.AssignmentJSON = assignmentJSON
+	default:
+		return nil, app_errors.ErrMediaNotEditable
+	}
+	return s.lessonRepo.UpdateContent(ctx, existing)
+}
+
+func (s *LessonContentService) DeleteContent(ctx context.Context, contentID, authorID uuid.UUID) error {
+	content, err := s.lessonRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		return err
+	}
+	lesson, err := s.authorLesson(ctx, content.LessonID, authorID)
+	if err != nil {
+		return err
+	}
+	if err := s.lessonRepo.DeleteContentAndUpdateOrder(ctx, content.ID, content.LessonID, content.Order); err != nil {
+		return err
+	}
+	s.deleteMedia(ctx, content)
+	if content.Type == models.ContentTypeAssignment {
+		// the submission rows go with the content, their files have to be removed from storage
+		if err := s.mediaStorage.DeleteAssignmentSubmissions(ctx, lesson.CourseID, content.ID); err != nil {
+			s.log.ErrorErr("failed to delete assignment submissions from minio", err, "content_id", content.ID.String())
+		}
+	}
+	return nil
+}
+
+func (s *LessonContentService) ReorderContents(ctx context.Context, lessonID uuid.UUID, contentIDs []uuid.UUID, authorID uuid.UUID) error {
+	if _, err := s.authorLesson(ctx, lessonID, authorID); err != nil {
+		return err
+	}
+	return s.lessonRepo.ReorderContents(ctx, lessonID, contentIDs)
+}
+
+func (s *LessonContentService) authorLesson(ctx context.Context, lessonID, authorID uuid.UUID) (models.Lesson, error) {
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return models.Lesson{}, err
+	}
+	course, err := s.courseRepo.CourseByID(ctx, lesson.CourseID)
+	if err != nil {
+		return models.Lesson{}, err
+	}
+	if course.AuthorID != authorID {
+		return models.Lesson{}, app_errors.ErrNotCourseAuthor
+	}
+	return lesson, nil
+}
+
+func (s *LessonContentService) saveContent(ctx context.Context, content models.CourseContent) (*models.CourseContent, error) {
+	if content.Order > 0 {
+		return s.lessonRepo.InsertContent(ctx, content)
+	}
+	return s.lessonRepo.CreateContent(ctx, content)
+}
+
+func (s *LessonContentService) deleteMedia(ctx context.Context, content models.CourseContent) {
+	if content.ObjectKey == nil {
+		return
+	}
+	switch content.Type {
+	case models.ContentTypeImage:
+		if err := s.mediaStorage.DeletePhoto(ctx, *content.ObjectKey); err != nil {
+			s.log.ErrorErr("failed to delete image from minio", err)
+		}
+	case models.ContentTypeVideo:
+		if err := s.mediaStorage.DeleteVideo(ctx, *content.ObjectKey); err != nil {
+			s.log.ErrorErr("failed to delete video from minio", err)
+		}
+	}
+}
+
+func (s *LessonContentService) CourseContent(ctx context.Context, courseID, viewerID uuid.UUID) ([]models.Contents, error) {
+	if _, err := s.visibleCourse(ctx, courseID, viewerID); err != nil {
+		return nil, err
+	}
 	return s.lessonRepo.CourseContent(ctx, courseID)
 }
+
+// markViewed completes a lesson without quizzes and assignments when a subscribed learner opens it;
+// failures are only logged, they must not keep the lesson from being shown
+func (s *LessonContentService) markViewed(ctx context.Context, detail models.LessonDetail, viewerID uuid.UUID) {
+	for _, content := range detail.Contents {
+		if content.Type == models.ContentTypeQuiz || content.Type == models.ContentTypeAssignment {
+			return
+		}
+	}
+	subscribed, err := s.subRepo.IsSubscribed(ctx, detail.Lesson.CourseID, viewerID)
+	if err != nil {
+		s.log.ErrorErr("failed to check subscription for lesson view", err, "lesson_id", detail.Lesson.ID.String())
+		return
+	}
+	if !subscribed {
+		return
+	}
+	if err := s.lessonRepo.MarkLessonCompleted(ctx, detail.Lesson.ID, viewerID); err != nil {
+		s.log.ErrorErr("failed to complete viewed lesson", err, "lesson_id", detail.Lesson.ID.String())
+	}
+}
+
+// checkLessonAccess lets admins and the course author read any lesson with the answer keys
+// (fullAccess); everyone else needs a visible course and either a subscription or a free preview lesson
+func (s *LessonContentService) checkLessonAccess(ctx context.Context, lesson models.Lesson, viewerID uuid.UUID, viewerRoles []string) (fullAccess bool, err error) {
+	if slices.Contains(viewerRoles, models.AdminRole) {
+		return true, nil
+	}
+	course, err := s.visibleCourse(ctx, lesson.CourseID, viewerID)
+	if err != nil {
+		return false, err
+	}
+	if viewerID != uuid.Nil && course.AuthorID == viewerID {
+		return true, nil
+	}
+	if lesson.FreePreview {
+		return false, nil
+	}
+	if viewerID == uuid.Nil {
+		return false, app_errors.ErrLessonAccessDenied
+	}
+	subscribed, err := s.subRepo.IsSubscribed(ctx, course.ID, viewerID)
+	if err != nil {
+		return false, err
+	}
+	if !subscribed {
+		return false, app_errors.ErrLessonAccessDenied
+	}
+	return false, nil
+}
+
+// learnerQuiz never falls back to the stored JSON: a quiz that cannot be projected is not shown at all
+func (s *LessonContentService) learnerQuiz(content models.CourseContent) *string {
+	if content.QuizJSON == nil {
+		return nil
+	}
+	var quiz models.QuizJSON
+	if err := json.Unmarshal([]byte(*content.QuizJSON), &quiz); err != nil {
+		s.log.ErrorErr("failed to parse quiz for learner view", err, "content_id", content.ID.String())
+		return nil
+	}
+	view := quiz.LearnerView()
+	if quiz.Randomized() {
+		// the pool stays hidden, each attempt gets its questions from the start endpoint
+		view.Questions = []models.LearnerQuestion{}
+	}
+	data, err := json.Marshal(view)
+	if err != nil {
+		s.log.ErrorErr("failed to build learner quiz", err, "content_id", content.ID.String())
+		return nil
+	}
+	result := string(data)
+	return &result
+}
+
+func (s *LessonContentService) visibleCourse(ctx context.Context, courseID, viewerID uuid.UUID) (*models.Course, error) {
+	course, err := s.courseRepo.CourseByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if !course.VisibleTo(viewerID) {
+		return nil, app_errors.ErrCourseNotFound
+	}
+	return course, nil
+}
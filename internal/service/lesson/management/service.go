@@ -26,11 +26,13 @@ type lessonRepo interface {
 	DeleteLessonAndUpdateOrder(ctx context.Context, lessonID, moduleID uuid.UUID, lessonOrder int) error
 	DeleteModuleAndUpdateOrder(ctx context.Context, moduleID, courseID uuid.UUID, moduleOrder int) error
 	LessonsByModule(ctx context.Context, moduleID uuid.UUID) ([]models.Lesson, error)
+	SetLessonFreePreview(ctx context.Context, lessonID uuid.UUID, freePreview bool) error
 }
 
 type mediaStorage interface {
 	DeleteVideo(ctx context.Context, objectKey string) error
 	DeletePhoto(ctx context.Context, objectKey string) error
+	DeleteAssignmentSubmissions(ctx context.Context, courseID, contentID uuid.UUID) error
 }
 
 type LessonManagementService struct {
@@ -170,6 +172,11 @@ func (s *LessonManagementService) DeleteLesson(ctx context.Context, courseID, le
 				}
 			}
 		}
+		if content.Type == models.ContentTypeAssignment {
+			if err := s.mediaStorage.DeleteAssignmentSubmissions(ctx, courseID, content.ID); err != nil {
+				s.log.Error("failed to delete assignment submissions from minio", err)
+			}
+		}
 	}
 
 	return s.lessonRepo.DeleteLessonAndUpdateOrder(ctx, lessonID, moduleID, detail.Lesson.LessonOrder)
@@ -212,8 +219,31 @@ func (s *LessonManagementService) DeleteModule(ctx context.Context, courseID, mo
 					}
 				}
 			}
+			if content.Type == models.ContentTypeAssignment {
+				if err := s.mediaStorage.DeleteAssignmentSubmissions(ctx, courseID, content.ID); err != nil {
+					s.log.Error("failed to delete assignment submissions from MinIO", err)
+				}
+			}
 		}
 	}
 
 	return s.lessonRepo.DeleteModuleAndUpdateOrder(ctx, moduleID, courseID, module.Order)
 }
+
+func (s *LessonManagementService) SetFreePreview(ctx context.Context, courseID, lessonID, authorID uuid.UUID, freePreview bool) error {
+	lesson, err := s.lessonRepo.GetLessonByID(ctx, lessonID)
+	if err != nil {
+		return err
+	}
+	if lesson.CourseID != courseID {
+		return app_errors.ErrLessonNotFound
+	}
+	course, err := s.courseRepo.CourseByID(ctx, courseID)
+	if err != nil {
+		return err
+	}
+	if course.AuthorID != authorID {
+		return app_errors.ErrNotCourseAuthor
+	}
+	return s.lessonRepo.SetLessonFreePreview(ctx, lessonID, freePreview)
+}
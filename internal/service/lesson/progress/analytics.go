@@ -0,0 +1,264 @@
+package progress
+
+import (
+	"SkillForge/internal/models"
+	"context"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// share of attempts in the top and bottom groups of the discrimination index
+	discriminationGroup = 0.27
+	// questions served fewer times are not flagged, their rates are too noisy
+	minFlagSample = 10
+)
+
+// QuizAnalytics computes per-question statistics over all submitted attempts at a quiz block for the course author
+func (s *LessonProgressService) QuizAnalytics(ctx context.Context, courseID, lessonID, contentID, authorID uuid.UUID) (*models.QuizAnalytics, error) {
+	contentID, quiz, err := s.authorQuiz(ctx, courseID, lessonID, contentID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.attemptRepo.SubmittedAttempts(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+	return quizAnalytics(contentID, quiz, attempts), nil
+}
+
+// quizAnalytics expects attempts oldest first; the first attempt of each learner feeds the first-attempt rates
+func quizAnalytics(contentID uuid.UUID, quiz models.QuizJSON, attempts []models.QuizAttempt) *models.QuizAnalytics {
+	analytics := &models.QuizAnalytics{
+		ContentID: contentID,
+		Attempts:  len(attempts),
+		Questions: make([]models.QuestionAnalytics, 0, len(quiz.Questions)),
+	}
+
+	firstAttempt := make(map[uuid.UUID]bool)
+	isFirst := make([]bool, len(attempts))
+	var scoreTotal, durationTotal float64
+	passed, firstPassed, timed := 0, 0, 0
+	for i, attempt := range attempts {
+		scoreTotal += attempt.Score
+		if attempt.Status == models.LessonStatusPassed {
+			passed++
+		}
+		if !firstAttempt[attempt.UserID] {
+			firstAttempt[attempt.UserID] = true
+			isFirst[i] = true
+			if attempt.Status == models.LessonStatusPassed {
+				firstPassed++
+			}
+		}
+		if duration := attemptDuration(attempt); duration > 0 {
+			durationTotal += duration
+			timed++
+		}
+	}
+	analytics.Learners = len(firstAttempt)
+	if len(attempts) > 0 {
+		analytics.AverageScore = scoreTotal / float64(len(attempts))
+		analytics.PassRate = percent(passed, len(attempts))
+		analytics.FirstAttemptPassRate = percent(firstPassed, analytics.Learners)
+	}
+	if timed > 0 {
+		average := durationTotal / float64(timed)
+		analytics.AverageDurationSeconds = &average
+	}
+
+	for _, question := range quiz.Questions {
+		analytics.Questions = append(analytics.Questions, questionAnalytics(question, attempts, isFirst))
+	}
+	return analytics
+}
+
+// questionOutcome is the question's result in one attempt that served it
+type questionOutcome struct {
+	score   float64
+	correct bool
+}
+
+func questionAnalytics(question models.QuizQuestion, attempts []models.QuizAttempt, isFirst []bool) models.QuestionAnalytics {
+	qa := models.QuestionAnalytics{
+		QuestionID: question.ID,
+		Text:       question.Text,
+		Type:       question.Type,
+		Flags:      []string{},
+	}
+	selected := make(map[string]int, len(question.Options))
+	var outcomes []questionOutcome
+	var credit, timeTotal float64
+	correct, firstServed, firstCorrect, timed := 0, 0, 0, 0
+	for i, attempt := range attempts {
+		result, served := questionResult(attempt, question.ID)
+		if !served {
+			continue
+		}
+		qa.Served++
+		if result.MaxPoints > 0 {
+			credit += result.Points / result.MaxPoints
+		}
+		if result.Correct {
+			correct++
+		}
+		if isFirst[i] {
+			firstServed++
+			if result.Correct {
+				firstCorrect++
+			}
+		}
+		outcomes = append(outcomes, questionOutcome{score: attempt.Score, correct: result.Correct})
+
+		answer, answered := attemptAnswer(attempt, question.ID)
+		if !answered {
+			continue
+		}
+		qa.Answered++
+		for _, optionID := range answer.OptionIDs {
+			selected[optionID]++
+		}
+		if spent := answerTime(attempt, answer); spent > 0 {
+			timeTotal += spent
+			timed++
+		}
+	}
+
+	if qa.Served > 0 {
+		qa.CorrectRate = percent(correct, qa.Served)
+		qa.AverageCredit = credit / float64(qa.Served) * 100
+	}
+	if firstServed > 0 {
+		rate := percent(firstCorrect, firstServed)
+		qa.FirstAttemptCorrectRate = &rate
+	}
+	if timed > 0 {
+		average := timeTotal / float64(timed)
+		qa.AverageTimeSeconds = &average
+	}
+	qa.Discrimination = discrimination(outcomes)
+
+	if question.Type == models.QuestionTypeSingle || question.Type == models.QuestionTypeMultiple {
+		for _, option := range question.Options {
+			qa.Options = append(qa.Options, models.OptionAnalytics{
+				OptionID:      option.ID,
+				Text:          option.Text,
+				IsCorrect:     option.IsCorrect,
+				Selected:      selected[option.ID],
+				SelectionRate: percent(selected[option.ID], qa.Served),
+			})
+		}
+	}
+	qa.Flags = questionFlags(qa)
+	return qa
+}
+
+// discrimination ranks the attempts by score and compares the correct rate of the top group with the bottom one;
+// it is left out while the groups would be empty
+func discrimination(outcomes []questionOutcome) *float64 {
+	group := int(math.Round(float64(len(outcomes)) * discriminationGroup))
+	if group == 0 {
+		return nil
+	}
+	sort.SliceStable(outcomes, func(i, j int) bool { return outcomes[i].score > outcomes[j].score })
+	upper, lower := 0, 0
+	for i := 0; i < group; i++ {
+		if outcomes[i].correct {
+			upper++
+		}
+		if outcomes[len(outcomes)-1-i].correct {
+			lower++
+		}
+	}
+	index := float64(upper-lower) / float64(group)
+	return &index
+}
+
+// questionFlags points the author at items worth reviewing: questions almost nobody or almost everybody gets right,
+// questions that strong and weak learners answer alike, distractors nobody picks and distractors picked more often
+// than the right answer
+func questionFlags(qa models.QuestionAnalytics) []string {
+	flags := []string{}
+	if qa.Served < minFlagSample {
+		return flags
+	}
+	switch {
+	case qa.CorrectRate < 20:
+		flags = append(flags, models.QuestionFlagTooHard)
+	case qa.CorrectRate > 95:
+		flags = append(flags, models.QuestionFlagTooEasy)
+	}
+	if qa.Discrimination != nil && *qa.Discrimination < 0.2 {
+		flags = append(flags, models.QuestionFlagLowDiscrimination)
+	}
+
+	bestCorrect := 0
+	for _, option := range qa.Options {
+		if option.IsCorrect && option.Selected > bestCorrect {
+			bestCorrect = option.Selected
+		}
+	}
+	unused, overChosen := false, false
+	for _, option := range qa.Options {
+		if option.IsCorrect {
+			continue
+		}
+		unused = unused || option.Selected == 0
+		overChosen = overChosen || option.Selected > bestCorrect
+	}
+	if unused {
+		flags = append(flags, models.QuestionFlagUnusedDistractor)
+	}
+	if overChosen {
+		flags = append(flags, models.QuestionFlagDistractorOverChosen)
+	}
+	return flags
+}
+
+func questionResult(attempt models.QuizAttempt, questionID string) (models.QuestionResult, bool) {
+	for _, result := range attempt.Questions {
+		if result.QuestionID == questionID {
+			return result, true
+		}
+	}
+	return models.QuestionResult{}, false
+}
+
+// attemptAnswer returns the first answer to the question, the one grading used
+func attemptAnswer(attempt models.QuizAttempt, questionID string) (models.QuizAnswer, bool) {
+	for _, answer := range attempt.Answers {
+		if answer.QuestionID == questionID {
+			return answer, true
+		}
+	}
+	return models.QuizAnswer{}, false
+}
+
+// attemptDuration is zero for quizzes answered without starting an attempt, their start is the submission time
+func attemptDuration(attempt models.QuizAttempt) float64 {
+	if attempt.SubmittedAt == nil {
+		return 0
+	}
+	return attempt.SubmittedAt.Sub(attempt.StartedAt).Seconds()
+}
+
+// answerTime takes the time reported by the client, capped by the attempt duration when it is known
+func answerTime(attempt models.QuizAttempt, answer models.QuizAnswer) float64 {
+	spent := float64(answer.TimeSpent)
+	if spent <= 0 {
+		return 0
+	}
+	if duration := attemptDuration(attempt); duration > 0 && spent > duration {
+		return duration
+	}
+	return spent
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
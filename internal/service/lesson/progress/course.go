@@ -0,0 +1,96 @@
+package progress
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// CompleteLesson marks a lesson without quizzes and assignments as completed for a subscribed learner
+func (s *LessonProgressService) CompleteLesson(ctx context.Context, lessonID, userID uuid.UUID) (models.LessonProgress, error) {
+	detail, err := s.lessonRepo.GetLessonDetail(ctx, lessonID)
+	if err != nil {
+		return models.LessonProgress{}, err
+	}
+	if _, err := s.subscribedCourse(ctx, detail.Lesson.CourseID, userID); err != nil {
+		return models.LessonProgress{}, err
+	}
+	for _, content := range detail.Contents {
+		if content.Type == models.ContentTypeQuiz || content.Type == models.ContentTypeAssignment {
+			return models.LessonProgress{}, app_errors.ErrLessonGraded
+		}
+	}
+	if err := s.lessonRepo.MarkLessonCompleted(ctx, lessonID, userID); err != nil {
+		return models.LessonProgress{}, err
+	}
+	return s.lessonRepo.GetLessonProgress(ctx, lessonID, userID)
+}
+
+// CourseProgress returns the learner's completion of every module and of the whole course, with the first
+// lesson in outline order that is not completed yet
+func (s *LessonProgressService) CourseProgress(ctx context.Context, courseID, userID uuid.UUID) (*models.CourseProgress, error) {
+	if _, err := s.subscribedCourse(ctx, courseID, userID); err != nil {
+		return nil, err
+	}
+	modules, lessons, err := s.lessonRepo.CourseLessonProgress(ctx, courseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &models.CourseProgress{
+		CourseID: courseID,
+		Modules:  make([]models.ModuleProgress, 0, len(modules)),
+	}
+	byModule := make(map[uuid.UUID]int, len(modules))
+	for i, module := range modules {
+		byModule[module.ID] = i
+		progress.Modules = append(progress.Modules, models.ModuleProgress{
+			ModuleID: module.ID,
+			Title:    module.Title,
+			Lessons:  []models.LessonCompletion{},
+		})
+	}
+	for _, lesson := range lessons {
+		if lesson.Status == "" {
+			lesson.Status = models.BlockStatusNotStarted
+		}
+		module := &progress.Modules[byModule[lesson.ModuleID]]
+		module.Lessons = append(module.Lessons, lesson)
+		module.TotalLessons++
+		progress.TotalLessons++
+		if lesson.Completed {
+			module.CompletedLessons++
+			progress.CompletedLessons++
+		} else if progress.NextLesson == nil {
+			next := lesson
+			progress.NextLesson = &next
+		}
+	}
+	for i := range progress.Modules {
+		progress.Modules[i].Percent = percent(progress.Modules[i].CompletedLessons, progress.Modules[i].TotalLessons)
+	}
+	progress.Percent = percent(progress.CompletedLessons, progress.TotalLessons)
+	return progress, nil
+}
+
+// subscribedCourse loads the course for a learner; hidden courses are not found and only subscribers
+// may take quizzes and track progress
+func (s *LessonProgressService) subscribedCourse(ctx context.Context, courseID, userID uuid.UUID) (*models.Course, error) {
+	course, err := s.courseRepo.CourseByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if !course.VisibleTo(userID) {
+		return nil, app_errors.ErrCourseNotFound
+	}
+	subscribed, err := s.subRepo.IsSubscribed(ctx, courseID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !subscribed {
+		return nil, app_errors.ErrNotSubscribed
+	}
+	return course, nil
+}
@@ -0,0 +1,108 @@
+package progress
+
+import (
+	"SkillForge/internal/models"
+)
+
+// revealAnswers applies the quiz reveal policy to the learner's submitted attempts and lesson status.
+// after_submit needs an attempt that answered something, otherwise an empty submission would fetch the key.
+func revealAnswers(quiz models.QuizJSON, submitted []models.QuizAttempt, status string) bool {
+	switch quiz.RevealAnswers {
+	case models.RevealAfterSubmit:
+		for _, attempt := range submitted {
+			if len(attempt.Answers) > 0 {
+				return true
+			}
+		}
+		return false
+	case models.RevealAfterPassing:
+		return status == models.LessonStatusPassed
+	case models.RevealAfterLastAttempt:
+		return quiz.MaxAttempts > 0 && len(submitted) >= quiz.MaxAttempts
+	}
+	return false
+}
+
+// buildFeedback pairs every graded question of the attempt with the learner's answer and the author's
+// explanations. Questions removed from the quiz since the attempt keep only their grade.
+func buildFeedback(quiz models.QuizJSON, attempt models.QuizAttempt, reveal bool) []models.QuestionFeedback {
+	questions := make(map[string]models.QuizQuestion, len(quiz.Questions))
+	for _, question := range quiz.Questions {
+		questions[question.ID] = question
+	}
+	answers := make(map[string]models.QuizAnswer, len(attempt.Answers))
+	for _, answer := range attempt.Answers {
+		if _, ok := answers[answer.QuestionID]; !ok {
+			answers[answer.QuestionID] = answer
+		}
+	}
+
+	feedback := make([]models.QuestionFeedback, 0, len(attempt.Questions))
+	for _, result := range attempt.Questions {
+		item := models.QuestionFeedback{QuestionResult: result}
+		answer, answered := answers[result.QuestionID]
+		if answered {
+			item.Answer = &answer
+		}
+		if question, ok := questions[result.QuestionID]; ok {
+			item.Explanation = question.Explanation
+			if answered {
+				item.OptionExplanations = optionExplanations(question, answer)
+			}
+			if reveal {
+				item.CorrectAnswer = correctAnswer(question)
+			}
+		}
+		feedback = append(feedback, item)
+	}
+	return feedback
+}
+
+func optionExplanations(question models.QuizQuestion, answer models.QuizAnswer) []models.OptionExplanation {
+	var explanations []models.OptionExplanation
+	for _, optionID := range answer.OptionIDs {
+		if option, ok := optionByID(question, optionID); ok && option.Explanation != "" {
+			explanations = append(explanations, models.OptionExplanation{OptionID: option.ID, Explanation: option.Explanation})
+		}
+	}
+	return explanations
+}
+
+// correctAnswer expresses the answer key in the same shape the learner submits answers in
+func correctAnswer(question models.QuizQuestion) *models.QuizAnswer {
+	answer := models.QuizAnswer{QuestionID: question.ID}
+	switch question.Type {
+	case models.QuestionTypeSingle, models.QuestionTypeMultiple, "single_choice", "multiple_choice":
+		for _, option := range question.Options {
+			if option.IsCorrect {
+				answer.OptionIDs = append(answer.OptionIDs, option.ID)
+			}
+		}
+	case models.QuestionTypeOrdering:
+		for _, option := range question.Options {
+			answer.OptionIDs = append(answer.OptionIDs, option.ID)
+		}
+	case models.QuestionTypeText:
+		answer.TextAnswer = question.CorrectAnswer
+		if answer.TextAnswer == "" && len(question.AcceptedAnswers) > 0 {
+			answer.TextAnswer = question.AcceptedAnswers[0]
+		}
+	case models.QuestionTypeNumeric:
+		answer.NumberAnswer = question.NumericAnswer
+		if len(question.Units) > 0 {
+			answer.Unit = question.Units[0]
+		}
+	case models.QuestionTypeMatching:
+		for _, pair := range question.Pairs {
+			answer.Matches = append(answer.Matches, models.MatchAnswer{PromptID: pair.ID, MatchID: pair.MatchID})
+		}
+	case models.QuestionTypeCloze:
+		answer.Blanks = make(map[string]string, len(question.Blanks))
+		for _, blank := range question.Blanks {
+			if len(blank.AcceptedAnswers) > 0 {
+				answer.Blanks[blank.ID] = blank.AcceptedAnswers[0]
+			}
+		}
+	}
+	return &answer
+}
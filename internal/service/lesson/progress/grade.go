@@ -0,0 +1,273 @@
+package progress
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// gradeQuiz returns the score in percent of the quiz points and the points earned for each question
+func gradeQuiz(quiz models.QuizJSON, answers []models.QuizAnswer) (float64, []models.QuestionResult) {
+	byQuestion := make(map[string]models.QuizAnswer, len(answers))
+	for _, answer := range answers {
+		if _, ok := byQuestion[answer.QuestionID]; !ok {
+			byQuestion[answer.QuestionID] = answer
+		}
+	}
+
+	results := make([]models.QuestionResult, 0, len(quiz.Questions))
+	var earned, total float64
+	for _, question := range quiz.Questions {
+		result := models.QuestionResult{QuestionID: question.ID, MaxPoints: question.MaxPoints()}
+		if answer, answered := byQuestion[question.ID]; answered {
+			result.Points = result.MaxPoints * questionCredit(question, answer)
+		}
+		result.Correct = result.Points >= result.MaxPoints
+		earned += result.Points
+		total += result.MaxPoints
+		results = append(results, result)
+	}
+
+	if total == 0 {
+		return 0, results
+	}
+	return earned / total * 100, results
+}
+
+// questionCredit is the share of the question points the answer earns, from 0 to 1
+func questionCredit(question models.QuizQuestion, answer models.QuizAnswer) float64 {
+	switch question.Type {
+	case models.QuestionTypeSingle, "single_choice":
+		if len(answer.OptionIDs) != 1 {
+			return 0
+		}
+		if option, ok := optionByID(question, answer.OptionIDs[0]); ok && option.IsCorrect {
+			return 1
+		}
+	case models.QuestionTypeMultiple, "multiple_choice":
+		return multipleChoiceCredit(question, answer)
+	case models.QuestionTypeText:
+		accepted := question.AcceptedAnswers
+		if question.CorrectAnswer != "" {
+			accepted = append([]string{question.CorrectAnswer}, accepted...)
+		}
+		if textMatches(answer.TextAnswer, accepted, question.Pattern) {
+			return 1
+		}
+	case models.QuestionTypeNumeric:
+		if numericCorrect(question, answer) {
+			return 1
+		}
+	case models.QuestionTypeOrdering:
+		return partialCredit(question.Scoring, orderingMatches(question, answer), len(question.Options))
+	case models.QuestionTypeMatching:
+		return partialCredit(question.Scoring, matchingMatches(question, answer), len(question.Pairs))
+	case models.QuestionTypeCloze:
+		return partialCredit(question.Scoring, clozeMatches(question, answer), len(question.Blanks))
+	}
+	return 0
+}
+
+// partialCredit turns the number of correctly answered parts of a question into its credit
+func partialCredit(scoring string, right, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	if scoring == models.ScoringProportional {
+		return float64(right) / float64(total)
+	}
+	if right == total {
+		return 1
+	}
+	return 0
+}
+
+// textMatches accepts the answer if it equals one of the accepted answers, ignoring case and
+// surrounding spaces, or fully matches the author's regular expression
+func textMatches(answer string, accepted []string, pattern string) bool {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return false
+	}
+	for _, candidate := range accepted {
+		if candidate != "" && normalizeText(answer) == normalizeText(candidate) {
+			return true
+		}
+	}
+	if pattern == "" {
+		return false
+	}
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	return err == nil && re.MatchString(answer)
+}
+
+func numericCorrect(question models.QuizQuestion, answer models.QuizAnswer) bool {
+	if answer.NumberAnswer == nil || question.NumericAnswer == nil {
+		return false
+	}
+	if math.Abs(*answer.NumberAnswer-*question.NumericAnswer) > question.Tolerance {
+		return false
+	}
+	if len(question.Units) == 0 {
+		return true
+	}
+	for _, unit := range question.Units {
+		if normalizeText(unit) == normalizeText(answer.Unit) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderingMatches counts the items the learner put at their place; the stored option order is the right one
+func orderingMatches(question models.QuizQuestion, answer models.QuizAnswer) int {
+	right := 0
+	for i, option := range question.Options {
+		if i < len(answer.OptionIDs) && answer.OptionIDs[i] == option.ID {
+			right++
+		}
+	}
+	return right
+}
+
+func matchingMatches(question models.QuizQuestion, answer models.QuizAnswer) int {
+	chosen := make(map[string]string, len(answer.Matches))
+	for _, match := range answer.Matches {
+		chosen[match.PromptID] = match.MatchID
+	}
+	right := 0
+	for _, pair := range question.Pairs {
+		if chosen[pair.ID] == pair.MatchID {
+			right++
+		}
+	}
+	return right
+}
+
+func clozeMatches(question models.QuizQuestion, answer models.QuizAnswer) int {
+	right := 0
+	for _, blank := range question.Blanks {
+		if textMatches(answer.Blanks[blank.ID], blank.AcceptedAnswers, blank.Pattern) {
+			right++
+		}
+	}
+	return right
+}
+
+func multipleChoiceCredit(question models.QuizQuestion, answer models.QuizAnswer) float64 {
+	selected := make(map[string]bool, len(answer.OptionIDs))
+	for _, optionID := range answer.OptionIDs {
+		selected[optionID] = true
+	}
+	correctCount, selectedCorrect, selectedWrong := 0, 0, 0
+	for _, option := range question.Options {
+		if option.IsCorrect {
+			correctCount++
+		}
+		if !selected[option.ID] {
+			continue
+		}
+		if option.IsCorrect {
+			selectedCorrect++
+		} else {
+			selectedWrong++
+		}
+	}
+	if correctCount == 0 {
+		return 0
+	}
+
+	switch question.Scoring {
+	case models.ScoringProportional:
+		// the share of correct options ticked, less the share of wrong options ticked; an answer without
+		// a single correct option earns nothing
+		if selectedCorrect == 0 {
+			return 0
+		}
+		credit := float64(selectedCorrect) / float64(correctCount)
+		if wrongCount := len(question.Options) - correctCount; wrongCount > 0 {
+			credit -= float64(selectedWrong) / float64(wrongCount)
+		}
+		return max(0, credit)
+	case models.ScoringRightMinusWrong:
+		return max(0, float64(selectedCorrect-selectedWrong)/float64(correctCount))
+	default:
+		if selectedCorrect == correctCount && selectedWrong == 0 {
+			return 1
+		}
+		return 0
+	}
+}
+
+// validateAnswers rejects answers that reference questions or options the quiz does not have
+func validateAnswers(quiz models.QuizJSON, answers []models.QuizAnswer) error {
+	questions := make(map[string]models.QuizQuestion, len(quiz.Questions))
+	for _, question := range quiz.Questions {
+		questions[question.ID] = question
+	}
+	for _, answer := range answers {
+		question, ok := questions[answer.QuestionID]
+		if !ok {
+			return fmt.Errorf("%w: %q", app_errors.ErrUnknownQuizQuestion, answer.QuestionID)
+		}
+		for _, optionID := range answer.OptionIDs {
+			if _, ok := optionByID(question, optionID); !ok {
+				return fmt.Errorf("%w: %q in question %q", app_errors.ErrUnknownQuizOption, optionID, question.ID)
+			}
+		}
+		for _, match := range answer.Matches {
+			if !hasPair(question, match) {
+				return fmt.Errorf("%w: pair %q-%q in question %q", app_errors.ErrUnknownQuizOption, match.PromptID, match.MatchID, question.ID)
+			}
+		}
+		for blankID := range answer.Blanks {
+			if !hasBlank(question, blankID) {
+				return fmt.Errorf("%w: blank %q in question %q", app_errors.ErrUnknownQuizOption, blankID, question.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// hasPair checks that both sides of a learner's match exist, not that they belong together
+func hasPair(question models.QuizQuestion, match models.MatchAnswer) bool {
+	prompt, item := false, false
+	for _, pair := range question.Pairs {
+		prompt = prompt || pair.ID == match.PromptID
+		item = item || pair.MatchID == match.MatchID
+	}
+	return prompt && item
+}
+
+func hasBlank(question models.QuizQuestion, blankID string) bool {
+	for _, blank := range question.Blanks {
+		if blank.ID == blankID {
+			return true
+		}
+	}
+	return false
+}
+
+func optionByID(question models.QuizQuestion, optionID string) (models.QuizOption, bool) {
+	for _, option := range question.Options {
+		if option.ID == optionID {
+			return option, true
+		}
+	}
+	return models.QuizOption{}, false
+}
+
+func pointTotals(questions []models.QuestionResult) (points, maxPoints float64) {
+	for _, question := range questions {
+		points += question.Points
+		maxPoints += question.MaxPoints
+	}
+	return points, maxPoints
+}
+
+func normalizeText(text string) string {
+	return strings.ToLower(strings.TrimSpace(text))
+}
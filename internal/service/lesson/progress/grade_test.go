@@ -0,0 +1,101 @@
+package progress
+
+import (
+	"SkillForge/internal/models"
+	"math"
+	"testing"
+)
+
+func TestMultipleChoiceCredit(t *testing.T) {
+	// a, b are correct; c, d, e are wrong
+	options := []models.QuizOption{
+		{ID: "a", IsCorrect: true},
+		{ID: "b", IsCorrect: true},
+		{ID: "c"},
+		{ID: "d"},
+		{ID: "e"},
+	}
+	tests := []struct {
+		name     string
+		scoring  string
+		selected []string
+		want     float64
+	}{
+		{"all or nothing, exact", models.ScoringAllOrNothing, []string{"a", "b"}, 1},
+		{"all or nothing, missing one", models.ScoringAllOrNothing, []string{"a"}, 0},
+		{"all or nothing, extra wrong", models.ScoringAllOrNothing, []string{"a", "b", "c"}, 0},
+		{"all or nothing, empty", models.ScoringAllOrNothing, nil, 0},
+
+		{"proportional, exact", models.ScoringProportional, []string{"a", "b"}, 1},
+		{"proportional, half of correct", models.ScoringProportional, []string{"a"}, 0.5},
+		{"proportional, both correct and one wrong", models.ScoringProportional, []string{"a", "b", "c"}, 1 - 1.0/3},
+		{"proportional, one correct and one wrong", models.ScoringProportional, []string{"a", "c"}, 0.5 - 1.0/3},
+		{"proportional, one correct and two wrong", models.ScoringProportional, []string{"a", "c", "d"}, 0},
+		{"proportional, only wrong", models.ScoringProportional, []string{"c"}, 0},
+		{"proportional, everything", models.ScoringProportional, []string{"a", "b", "c", "d", "e"}, 0},
+		{"proportional, empty", models.ScoringProportional, nil, 0},
+
+		{"right minus wrong, exact", models.ScoringRightMinusWrong, []string{"a", "b"}, 1},
+		{"right minus wrong, half of correct", models.ScoringRightMinusWrong, []string{"a"}, 0.5},
+		{"right minus wrong, both correct and one wrong", models.ScoringRightMinusWrong, []string{"a", "b", "c"}, 0.5},
+		{"right minus wrong, one correct and one wrong", models.ScoringRightMinusWrong, []string{"a", "c"}, 0},
+		{"right minus wrong, only wrong", models.ScoringRightMinusWrong, []string{"c", "d"}, 0},
+		{"right minus wrong, empty", models.ScoringRightMinusWrong, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := models.QuizQuestion{Type: models.QuestionTypeMultiple, Options: options, Scoring: tt.scoring}
+			got := multipleChoiceCredit(question, models.QuizAnswer{OptionIDs: tt.selected})
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("credit = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProportionalSingleCorrectOption(t *testing.T) {
+	// one correct option out of four: a lone wrong tick or an empty answer must not earn partial credit
+	question := models.QuizQuestion{
+		Type:    models.QuestionTypeMultiple,
+		Scoring: models.ScoringProportional,
+		Options: []models.QuizOption{{ID: "a", IsCorrect: true}, {ID: "b"}, {ID: "c"}, {ID: "d"}},
+	}
+	for _, selected := range [][]string{nil, {"b"}, {"b", "c", "d"}} {
+		if got := multipleChoiceCredit(question, models.QuizAnswer{OptionIDs: selected}); got != 0 {
+			t.Errorf("credit for %v = %v, want 0", selected, got)
+		}
+	}
+	if got := multipleChoiceCredit(question, models.QuizAnswer{OptionIDs: []string{"a"}}); got != 1 {
+		t.Errorf("credit for the correct option = %v, want 1", got)
+	}
+}
+
+func TestGradeQuizPoints(t *testing.T) {
+	quiz := models.QuizJSON{Questions: []models.QuizQuestion{
+		{
+			ID:      "q1",
+			Type:    models.QuestionTypeMultiple,
+			Points:  4,
+			Scoring: models.ScoringProportional,
+			Options: []models.QuizOption{{ID: "a", IsCorrect: true}, {ID: "b", IsCorrect: true}, {ID: "c"}},
+		},
+		{
+			ID:      "q2",
+			Type:    models.QuestionTypeSingle,
+			Options: []models.QuizOption{{ID: "a", IsCorrect: true}, {ID: "b"}},
+		},
+	}}
+	score, results := gradeQuiz(quiz, []models.QuizAnswer{
+		{QuestionID: "q1", OptionIDs: []string{"a"}},
+		{QuestionID: "q2", OptionIDs: []string{"a"}},
+	})
+	if results[0].Points != 2 || results[0].Correct {
+		t.Errorf("q1 = %v points (correct %v), want 2 and not correct", results[0].Points, results[0].Correct)
+	}
+	if results[1].Points != 1 || !results[1].Correct {
+		t.Errorf("q2 = %v points (correct %v), want 1 and correct", results[1].Points, results[1].Correct)
+	}
+	if math.Abs(score-60) > 1e-9 {
+		t.Errorf("score = %v, want 60", score)
+	}
+}
@@ -0,0 +1,45 @@
+package progress
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"fmt"
+	"time"
+)
+
+// submitGrace covers the network delay of answers sent right at the deadline
+const submitGrace = 5 * time.Second
+
+// checkAttemptAllowed enforces the quiz attempt limit and the wait between attempts
+func checkAttemptAllowed(quiz models.QuizJSON, attempts []models.QuizAttempt, now time.Time) error {
+	if quiz.MaxAttempts > 0 && len(attempts) >= quiz.MaxAttempts {
+		return app_errors.ErrQuizAttemptsExhausted
+	}
+	if quiz.CooldownSeconds == 0 {
+		return nil
+	}
+	var last time.Time
+	for _, attempt := range attempts {
+		if attempt.SubmittedAt != nil && attempt.SubmittedAt.After(last) {
+			last = *attempt.SubmittedAt
+		}
+	}
+	if wait := last.Add(time.Duration(quiz.CooldownSeconds) * time.Second).Sub(now); wait > 0 {
+		return fmt.Errorf("%w: retry in %s", app_errors.ErrQuizCooldown, wait.Round(time.Second))
+	}
+	return nil
+}
+
+func pastDeadline(attempt *models.QuizAttempt, now time.Time) bool {
+	return attempt.Deadline != nil && now.After(attempt.Deadline.Add(submitGrace))
+}
+
+func submittedAttempts(attempts []models.QuizAttempt) []models.QuizAttempt {
+	submitted := make([]models.QuizAttempt, 0, len(attempts))
+	for _, attempt := range attempts {
+		if attempt.Status != models.AttemptStatusInProgress {
+			submitted = append(submitted, attempt)
+		}
+	}
+	return submitted
+}
@@ -0,0 +1,101 @@
+package progress
+
+import (
+	"SkillForge/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// blockFromAttempts folds the learner's attempts at a quiz block (oldest first, at least one) into
+// its result: the best attempt, the latest one, or the mean of all of them
+func blockFromAttempts(policy string, contentID uuid.UUID, attempts []models.QuizAttempt) models.BlockResult {
+	shown := policyAttempt(policy, attempts)
+	block := models.BlockResult{
+		ContentID: contentID,
+		Type:      models.ContentTypeQuiz,
+		Status:    shown.Status,
+		Score:     shown.Score,
+		MinScore:  shown.MinScore,
+	}
+
+	if policy == models.ScorePolicyAverage {
+		total := 0.0
+		for _, attempt := range attempts {
+			total += attempt.Score
+		}
+		block.Score = total / float64(len(attempts))
+		block.Status = models.LessonStatusFailed
+		if block.Score >= block.MinScore {
+			block.Status = models.LessonStatusPassed
+		}
+	}
+	return block
+}
+
+// policyAttempt is the attempt whose answers stand for the block result: the best one under the
+// best policy, otherwise the latest
+func policyAttempt(policy string, attempts []models.QuizAttempt) models.QuizAttempt {
+	latest := attempts[len(attempts)-1]
+	if policy == models.ScorePolicyLatest || policy == models.ScorePolicyAverage {
+		return latest
+	}
+	best := latest
+	for _, attempt := range attempts {
+		if attempt.Score > best.Score {
+			best = attempt
+		}
+	}
+	return best
+}
+
+// assignmentBlock takes the latest graded submission (submissions newest first); an ungraded one keeps the block pending
+func assignmentBlock(contentID uuid.UUID, submissions []models.AssignmentSubmission) models.BlockResult {
+	block := models.BlockResult{ContentID: contentID, Type: models.ContentTypeAssignment, Status: models.BlockStatusNotStarted}
+	for _, submission := range submissions {
+		if submission.Status == models.SubmissionStatusGraded {
+			block.Status = submission.Result
+			block.Score = submission.Score
+			block.MinScore = submission.MinScore
+			return block
+		}
+		block.Status = models.BlockStatusPending
+	}
+	return block
+}
+
+// lessonFromBlocks completes the lesson when every graded block is passed and fails it when any block is failed.
+// The score is the mean over all graded blocks, blocks without a result count as 0; the threshold is the mean
+// of the thresholds known so far.
+func lessonFromBlocks(lessonID, userID uuid.UUID, blocks []models.BlockResult) models.LessonProgress {
+	progress := models.LessonProgress{
+		UserID:   userID,
+		LessonID: lessonID,
+		Status:   models.LessonStatusPassed,
+		Blocks:   blocks,
+	}
+	failed := false
+	graded := 0
+	for _, block := range blocks {
+		progress.Score += block.Score
+		switch block.Status {
+		case models.LessonStatusPassed:
+		case models.LessonStatusFailed:
+			failed = true
+		default:
+			progress.Status = models.LessonStatusInProgress
+			continue
+		}
+		progress.MinScore += block.MinScore
+		graded++
+	}
+	if failed {
+		progress.Status = models.LessonStatusFailed
+	}
+	if len(blocks) > 0 {
+		progress.Score /= float64(len(blocks))
+	}
+	if graded > 0 {
+		progress.MinScore /= float64(graded)
+	}
+	return progress
+}
@@ -1,218 +1,398 @@
 package progress
 
 import (
+	"SkillForge/internal/app_errors"
 	"SkillForge/internal/models"
 	"SkillForge/pkg/logger"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
-	"strconv"
-	"strings"
+	"time"
 )
 
 type lessonRepo interface {
 	GetLessonDetail(ctx context.Context, lessonID uuid.UUID) (models.LessonDetail, error)
-	UpdateLessonProgress(ctx context.Context, lessonID, userID uuid.UUID, status string, score float64) error
+	UpdateLessonProgress(ctx context.Context, progress models.LessonProgress) error
 	GetLessonProgress(ctx context.Context, lessonID, userID uuid.UUID) (models.LessonProgress, error)
+	GetLessonByID(ctx context.Context, id uuid.UUID) (models.Lesson, error)
+	MarkLessonCompleted(ctx context.Context, lessonID, userID uuid.UUID) error
+	CourseLessonProgress(ctx context.Context, courseID, userID uuid.UUID) ([]models.Module, []models.LessonCompletion, error)
 }
+
+type courseRepo interface {
+	CourseByID(ctx context.Context, id uuid.UUID) (*models.Course, error)
+}
+
+type attemptRepo interface {
+	CreateAttempt(ctx context.Context, attempt *models.QuizAttempt, allow func([]models.QuizAttempt) error) error
+	OpenAttempt(ctx context.Context, contentID, userID uuid.UUID) (*models.QuizAttempt, error)
+	FinishAttempt(ctx context.Context, attempt *models.QuizAttempt) error
+	UserAttempts(ctx context.Context, contentID, userID uuid.UUID) ([]models.QuizAttempt, error)
+	ContentAttempts(ctx context.Context, contentID, userID uuid.UUID, limit, offset int) ([]models.QuizAttempt, error)
+	SubmittedAttempts(ctx context.Context, contentID uuid.UUID) ([]models.QuizAttempt, error)
+}
+
+type submissionRepo interface {
+	UserSubmissions(ctx context.Context, contentID, userID uuid.UUID) ([]models.AssignmentSubmission, error)
+}
+
+type subscriptionRepo interface {
+	IsSubscribed(ctx context.Context, courseID, userID uuid.UUID) (bool, error)
+}
+
 type LessonProgressService struct {
-	log        logger.Log
-	lessonRepo lessonRepo
+	log            logger.Log
+	lessonRepo     lessonRepo
+	courseRepo     courseRepo
+	attemptRepo    attemptRepo
+	submissionRepo submissionRepo
+	subRepo        subscriptionRepo
 }
 
-func NewLessonProgressService(log logger.Log, l lessonRepo) *LessonProgressService {
+func NewLessonProgressService(log logger.Log, l lessonRepo, c courseRepo, a attemptRepo, s submissionRepo, sub subscriptionRepo) *LessonProgressService {
 	return &LessonProgressService{
-		log:        log,
-		lessonRepo: l,
+		log:            log,
+		lessonRepo:     l,
+		courseRepo:     c,
+		attemptRepo:    a,
+		submissionRepo: s,
+		subRepo:        sub,
 	}
 }
 
-func (s *LessonProgressService) SubmitQuizAnswers(ctx context.Context, lessonID uuid.UUID, userID uuid.UUID, answers []models.QuizAnswer) (float64, error) {
+// SubmitQuizAnswers grades the answers to a quiz block; uuid.Nil stands for the first quiz of the lesson
+func (s *LessonProgressService) SubmitQuizAnswers(ctx context.Context, lessonID, contentID, userID uuid.UUID, answers []models.QuizAnswer) (*models.QuizResult, error) {
 	detail, err := s.lessonRepo.GetLessonDetail(ctx, lessonID)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	course, err := s.subscribedCourse(ctx, detail.Lesson.CourseID, userID)
+	if err != nil {
+		return nil, err
+	}
+	contentID, quiz, err := lessonQuiz(detail, contentID)
+	if err != nil {
+		return nil, err
 	}
 
-	var quizContent *models.CourseContent
-	for _, content := range detail.Contents {
-		if content.Type == models.ContentTypeQuiz {
-			quizContent = &content
-			break
+	now := time.Now().UTC()
+	var attempt *models.QuizAttempt
+	if quiz.RequiresStart() {
+		attempt, err = s.attemptRepo.OpenAttempt(ctx, contentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		attempt.Late = pastDeadline(attempt, now)
+		if attempt.Late && quiz.LateSubmission != models.LateSubmissionGrade {
+			if err := s.expireAttempt(ctx, course, detail, attempt); err != nil {
+				return nil, err
+			}
+			return nil, app_errors.ErrQuizTimeExpired
 		}
+	} else {
+		attempt = &models.QuizAttempt{UserID: userID, LessonID: lessonID, ContentID: contentID, StartedAt: now}
 	}
 
-	if quizContent == nil || quizContent.QuizJSON == nil {
-		return 0, fmt.Errorf("quiz not found in lesson")
+	// only the questions served in the attempt are answered and graded
+	served := quiz.Served(attempt.Served)
+	if err := validateAnswers(served, answers); err != nil {
+		return nil, err
+	}
+	attempt.Answers = answers
+	attempt.Score, attempt.Questions = gradeQuiz(served, answers)
+	attempt.MinScore = passThreshold(quiz, course)
+	attempt.Status = models.LessonStatusFailed
+	if attempt.Score >= attempt.MinScore {
+		attempt.Status = models.LessonStatusPassed
+	}
+	attempt.SubmittedAt = &now
+	if quiz.RequiresStart() {
+		err = s.attemptRepo.FinishAttempt(ctx, attempt)
+	} else {
+		// the attempt limit is checked together with the insert, see CreateAttempt
+		err = s.attemptRepo.CreateAttempt(ctx, attempt, func(attempts []models.QuizAttempt) error {
+			return checkAttemptAllowed(quiz, attempts, now)
+		})
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	s.log.Info("!!quizContent", quizContent)
-	s.log.Info("!!quizContent.QuizJSON", *quizContent.QuizJSON)
+	attempts, err := s.submittedAttempts(ctx, contentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	block := blockFromAttempts(course.ScorePolicy, contentID, attempts)
+	lesson, err := s.recalculate(ctx, course, detail, userID)
+	if err != nil {
+		return nil, err
+	}
+	reveal := revealAnswers(quiz, attempts, block.Status)
+	result := &models.QuizResult{
+		ContentID:    contentID,
+		AttemptID:    &attempt.ID,
+		Attempts:     len(attempts),
+		Late:         attempt.Late,
+		Score:        attempt.Score,
+		MinScore:     attempt.MinScore,
+		Status:       attempt.Status,
+		Questions:    buildFeedback(quiz, *attempt, reveal),
+		LessonStatus: lesson.Status,
+	}
+	result.Points, result.MaxPoints = pointTotals(attempt.Questions)
+	return result, nil
+}
 
-	var quiz models.QuizJSON
-	if err := json.Unmarshal([]byte(*quizContent.QuizJSON), &quiz); err != nil {
-		s.log.Error("Failed to unmarshal quiz JSON", err)
-		s.log.Error("Quiz JSON content:", *quizContent.QuizJSON)
-		return 0, fmt.Errorf("invalid quiz format: %w", err)
-	}
-
-	s.log.Info("Parsed quiz:", quiz)
-	s.log.Info("User answers:", answers)
-	score := 0.0
-	answeredQuestions := 0.0
-	totalQuestions := float64(len(quiz.Questions))
-
-	for _, question := range quiz.Questions {
-		s.log.Info("Processing question:", question)
-		var userAnswer *models.QuizAnswer
-		for _, answer := range answers {
-			if answer.QuestionID == question.ID {
-				userAnswer = &answer
-				break
-			}
-		}
+// StartQuiz opens an attempt of a timed or randomized quiz: the deadline is fixed on the server
+// and the questions are drawn for this attempt. Calling it again while the attempt is running
+// returns the same attempt.
+func (s *LessonProgressService) StartQuiz(ctx context.Context, lessonID, contentID, userID uuid.UUID) (*models.StartedQuiz, error) {
+	detail, err := s.lessonRepo.GetLessonDetail(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	course, err := s.subscribedCourse(ctx, detail.Lesson.CourseID, userID)
+	if err != nil {
+		return nil, err
+	}
+	contentID, quiz, err := lessonQuiz(detail, contentID)
+	if err != nil {
+		return nil, err
+	}
+	if !quiz.RequiresStart() {
+		return nil, app_errors.ErrQuizStartNotRequired
+	}
 
-		if question.Required && userAnswer == nil {
-			s.log.Info("Skipping required question without answer:", question.ID)
-			continue
+	now := time.Now().UTC()
+	open, err := s.attemptRepo.OpenAttempt(ctx, contentID, userID)
+	switch {
+	case err == nil:
+		if !pastDeadline(open, now) {
+			return &models.StartedQuiz{Attempt: *open, Quiz: quiz.ServedView(open.Served)}, nil
 		}
-
-		answeredQuestions++
-		s.log.Info("Answered questions count:", answeredQuestions)
-
-		if userAnswer != nil {
-			s.log.Info("Processing user answer:", userAnswer)
-			switch question.Type {
-			case "single", "single_choice":
-				if len(userAnswer.OptionIDs) == 1 {
-					optionIndex := 0
-					if strings.HasPrefix(userAnswer.OptionIDs[0], "option_") {
-						optionIndex, _ = strconv.Atoi(strings.TrimPrefix(userAnswer.OptionIDs[0], "option_"))
-					}
-					s.log.Info("Single choice answer details:", map[string]interface{}{
-						"optionIndex":    optionIndex,
-						"totalOptions":   len(question.Options),
-						"selectedOption": question.Options[optionIndex],
-					})
-					if optionIndex < len(question.Options) && question.Options[optionIndex].IsCorrect {
-						score++
-						s.log.Info("Correct single choice answer, score:", score)
-					}
-				}
-			case "multiple", "multiple_choice":
-				correctCount := 0
-				for _, option := range question.Options {
-					if option.IsCorrect {
-						correctCount++
-					}
-				}
-				s.log.Info("Multiple choice question, correct options count:", correctCount)
-				if correctCount > 0 {
-					userCorrectCount := 0
-					userIncorrectCount := 0
-					for _, optionID := range userAnswer.OptionIDs {
-						optionIndex := 0
-						if strings.HasPrefix(optionID, "option_") {
-							optionIndex, _ = strconv.Atoi(strings.TrimPrefix(optionID, "option_"))
-						}
-						if optionIndex < len(question.Options) {
-							if question.Options[optionIndex].IsCorrect {
-								userCorrectCount++
-							} else {
-								userIncorrectCount++
-							}
-						}
-					}
-					s.log.Info("Multiple choice answer details:", map[string]interface{}{
-						"userCorrectCount":   userCorrectCount,
-						"userIncorrectCount": userIncorrectCount,
-						"totalCorrectCount":  correctCount,
-					})
-					if userCorrectCount == correctCount && userIncorrectCount == 0 {
-						score++
-						s.log.Info("Correct multiple choice answer, score:", score)
-					}
-				}
-			case "text":
-				if userAnswer.TextAnswer != "" && question.CorrectAnswer != "" {
-					normalizedUserAnswer := normalizeText(userAnswer.TextAnswer)
-					normalizedCorrectAnswer := normalizeText(question.CorrectAnswer)
-					s.log.Info("Text answer comparison:", map[string]string{
-						"user":    normalizedUserAnswer,
-						"correct": normalizedCorrectAnswer,
-					})
-					if normalizedUserAnswer == normalizedCorrectAnswer {
-						score++
-						s.log.Info("Correct text answer, score:", score)
-					}
-				}
-			}
+		if err := s.expireAttempt(ctx, course, detail, open); err != nil {
+			return nil, err
 		}
+	case !errors.Is(err, app_errors.ErrQuizNotStarted):
+		return nil, err
 	}
 
-	finalScore := 0.0
-	if totalQuestions > 0 {
-		finalScore = (score / totalQuestions) * 100
+	attempt := models.QuizAttempt{
+		ID:        uuid.New(),
+		UserID:    userID,
+		LessonID:  lessonID,
+		ContentID: contentID,
+		Answers:   []models.QuizAnswer{},
+		Questions: []models.QuestionResult{},
+		MinScore:  passThreshold(quiz, course),
+		Status:    models.AttemptStatusInProgress,
+		StartedAt: now,
+	}
+	if quiz.Timed() {
+		deadline := now.Add(time.Duration(quiz.TimeLimitSeconds) * time.Second)
+		attempt.Deadline = &deadline
+	}
+	if quiz.Randomized() {
+		attempt.Served = drawQuestions(quiz, attempt.ID)
 	}
-	s.log.Info("Final score calculation:", map[string]float64{
-		"score":          score,
-		"totalQuestions": totalQuestions,
-		"finalScore":     finalScore,
+	err = s.attemptRepo.CreateAttempt(ctx, &attempt, func(attempts []models.QuizAttempt) error {
+		return checkAttemptAllowed(quiz, attempts, now)
 	})
-
-	status := models.LessonStatusFailed
-	if finalScore == 100 {
-		status = models.LessonStatusPassed
+	if err != nil {
+		return nil, err
 	}
-	s.log.Info("Final status:", status)
+	return &models.StartedQuiz{Attempt: attempt, Quiz: quiz.ServedView(attempt.Served)}, nil
+}
 
-	if err := s.lessonRepo.UpdateLessonProgress(ctx, lessonID, userID, status, finalScore); err != nil {
-		return 0, fmt.Errorf("failed to update lesson progress: %w", err)
+// expireAttempt closes an attempt whose time ran out without accepted answers, it counts as a failed try
+func (s *LessonProgressService) expireAttempt(ctx context.Context, course *models.Course, detail models.LessonDetail, attempt *models.QuizAttempt) error {
+	attempt.Score = 0
+	attempt.Status = models.LessonStatusFailed
+	attempt.Late = true
+	attempt.SubmittedAt = attempt.Deadline
+	if err := s.attemptRepo.FinishAttempt(ctx, attempt); err != nil {
+		return err
 	}
-
-	return finalScore, nil
+	_, err := s.recalculate(ctx, course, detail, attempt.UserID)
+	return err
 }
 
-func (s *LessonProgressService) GetQuizResult(ctx context.Context, lessonID, userID uuid.UUID) (float64, string, error) {
+// RecalculateLesson rebuilds the learner's lesson_progress row after one of its blocks got a new result
+func (s *LessonProgressService) RecalculateLesson(ctx context.Context, lessonID, userID uuid.UUID) (models.LessonProgress, error) {
 	detail, err := s.lessonRepo.GetLessonDetail(ctx, lessonID)
 	if err != nil {
-		return 0, "", err
+		return models.LessonProgress{}, err
+	}
+	course, err := s.courseRepo.CourseByID(ctx, detail.Lesson.CourseID)
+	if err != nil {
+		return models.LessonProgress{}, err
 	}
+	return s.recalculate(ctx, course, detail, userID)
+}
 
-	var quizContent *models.CourseContent
+// recalculate collects the results of every quiz and assignment block of the lesson and stores the lesson
+// completion computed from them
+func (s *LessonProgressService) recalculate(ctx context.Context, course *models.Course, detail models.LessonDetail, userID uuid.UUID) (models.LessonProgress, error) {
+	blocks := make([]models.BlockResult, 0)
 	for _, content := range detail.Contents {
-		if content.Type == models.ContentTypeQuiz {
-			quizContent = &content
-			break
+		switch content.Type {
+		case models.ContentTypeQuiz:
+			attempts, err := s.submittedAttempts(ctx, content.ID, userID)
+			if err != nil {
+				return models.LessonProgress{}, err
+			}
+			if len(attempts) == 0 {
+				blocks = append(blocks, models.BlockResult{ContentID: content.ID, Type: content.Type, Status: models.BlockStatusNotStarted})
+				continue
+			}
+			blocks = append(blocks, blockFromAttempts(course.ScorePolicy, content.ID, attempts))
+		case models.ContentTypeAssignment:
+			submissions, err := s.submissionRepo.UserSubmissions(ctx, content.ID, userID)
+			if err != nil {
+				return models.LessonProgress{}, err
+			}
+			blocks = append(blocks, assignmentBlock(content.ID, submissions))
 		}
 	}
 
-	if quizContent == nil || quizContent.QuizJSON == nil {
-		return 0, "", fmt.Errorf("quiz not found in lesson")
+	progress := lessonFromBlocks(detail.Lesson.ID, userID, blocks)
+	if len(blocks) == 0 {
+		return progress, nil
 	}
+	if err := s.lessonRepo.UpdateLessonProgress(ctx, progress); err != nil {
+		return models.LessonProgress{}, fmt.Errorf("failed to update lesson progress: %w", err)
+	}
+	return progress, nil
+}
 
-	var quiz models.QuizJSON
-	if err := json.Unmarshal([]byte(*quizContent.QuizJSON), &quiz); err != nil {
-		return 0, "", fmt.Errorf("invalid quiz format: %w", err)
+func (s *LessonProgressService) submittedAttempts(ctx context.Context, contentID, userID uuid.UUID) ([]models.QuizAttempt, error) {
+	attempts, err := s.attemptRepo.UserAttempts(ctx, contentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return submittedAttempts(attempts), nil
+}
+
+// GetQuizResult returns the score counted for the quiz block under the course score policy, with feedback
+// on the attempt that score comes from
+func (s *LessonProgressService) GetQuizResult(ctx context.Context, lessonID, contentID, userID uuid.UUID) (*models.QuizResult, error) {
+	detail, err := s.lessonRepo.GetLessonDetail(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	course, err := s.subscribedCourse(ctx, detail.Lesson.CourseID, userID)
+	if err != nil {
+		return nil, err
+	}
+	contentID, quiz, err := lessonQuiz(detail, contentID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.submittedAttempts(ctx, contentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attempts) == 0 {
+		return nil, app_errors.ErrQuizNotTaken
+	}
+
+	block := blockFromAttempts(course.ScorePolicy, contentID, attempts)
+	shown := policyAttempt(course.ScorePolicy, attempts)
+	result := &models.QuizResult{
+		ContentID:   contentID,
+		AttemptID:   &shown.ID,
+		ScorePolicy: course.ScorePolicy,
+		Attempts:    len(attempts),
+		Score:       block.Score,
+		MinScore:    block.MinScore,
+		Status:      block.Status,
+		Questions:   buildFeedback(quiz, shown, revealAnswers(quiz, attempts, block.Status)),
+	}
+	result.Points, result.MaxPoints = pointTotals(shown.Questions)
+	return result, nil
+}
+
+// GetLessonProgress returns the lesson completion with the result of every graded block
+func (s *LessonProgressService) GetLessonProgress(ctx context.Context, lessonID, userID uuid.UUID) (models.LessonProgress, error) {
+	if _, err := s.lessonRepo.GetLessonByID(ctx, lessonID); err != nil {
+		return models.LessonProgress{}, err
+	}
+	return s.lessonRepo.GetLessonProgress(ctx, lessonID, userID)
+}
+
+func (s *LessonProgressService) MyAttempts(ctx context.Context, lessonID, contentID, userID uuid.UUID) ([]models.QuizAttempt, error) {
+	detail, err := s.lessonRepo.GetLessonDetail(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.subscribedCourse(ctx, detail.Lesson.CourseID, userID); err != nil {
+		return nil, err
+	}
+	contentID, _, err = lessonQuiz(detail, contentID)
+	if err != nil {
+		return nil, err
 	}
+	return s.attemptRepo.UserAttempts(ctx, contentID, userID)
+}
 
-	progress, err := s.lessonRepo.GetLessonProgress(ctx, lessonID, userID)
+// LessonAttempts lets the course author review learners' submissions to a quiz block; learnerID may be
+// uuid.Nil for all learners
+func (s *LessonProgressService) LessonAttempts(ctx context.Context, courseID, lessonID, contentID, authorID, learnerID uuid.UUID, limit, offset int) ([]models.QuizAttempt, error) {
+	contentID, _, err := s.authorQuiz(ctx, courseID, lessonID, contentID, authorID)
 	if err != nil {
-		return 0, "", fmt.Errorf("quiz not taken yet")
+		return nil, err
 	}
+	return s.attemptRepo.ContentAttempts(ctx, contentID, learnerID, limit, offset)
+}
 
-	if progress.Score == 0 && progress.Status == "" {
-		return 0, "", fmt.Errorf("quiz not taken yet")
+// authorQuiz finds the quiz block of a lesson in the author's course, uuid.Nil picks the first quiz of the lesson
+func (s *LessonProgressService) authorQuiz(ctx context.Context, courseID, lessonID, contentID, authorID uuid.UUID) (uuid.UUID, models.QuizJSON, error) {
+	detail, err := s.lessonRepo.GetLessonDetail(ctx, lessonID)
+	if err != nil {
+		return uuid.Nil, models.QuizJSON{}, err
+	}
+	if detail.Lesson.CourseID != courseID {
+		return uuid.Nil, models.QuizJSON{}, app_errors.ErrLessonNotFound
+	}
+	course, err := s.courseRepo.CourseByID(ctx, courseID)
+	if err != nil {
+		return uuid.Nil, models.QuizJSON{}, err
 	}
+	if course.AuthorID != authorID {
+		return uuid.Nil, models.QuizJSON{}, app_errors.ErrNotCourseAuthor
+	}
+	return lessonQuiz(detail, contentID)
+}
 
-	status := models.LessonStatusFailed
-	if progress.Score == 100 {
-		status = models.LessonStatusPassed
+// lessonQuiz finds the quiz block with contentID in the lesson, uuid.Nil picks the first quiz of the lesson
+func lessonQuiz(detail models.LessonDetail, contentID uuid.UUID) (uuid.UUID, models.QuizJSON, error) {
+	var quizContent *models.CourseContent
+	for i := range detail.Contents {
+		if detail.Contents[i].Type != models.ContentTypeQuiz {
+			continue
+		}
+		if contentID == uuid.Nil || detail.Contents[i].ID == contentID {
+			quizContent = &detail.Contents[i]
+			break
+		}
+	}
+	if quizContent == nil || quizContent.QuizJSON == nil {
+		return uuid.Nil, models.QuizJSON{}, app_errors.ErrQuizNotFound
 	}
 
-	return progress.Score, status, nil
+	var quiz models.QuizJSON
+	if err := json.Unmarshal([]byte(*quizContent.QuizJSON), &quiz); err != nil {
+		return uuid.Nil, models.QuizJSON{}, fmt.Errorf("invalid quiz format: %w", err)
+	}
+	return quizContent.ID, quiz, nil
 }
 
-func normalizeText(text string) string {
-	return strings.ToLower(strings.TrimSpace(text))
+// passThreshold takes the quiz's own minScore, 0 included, and falls back to the course default when it is not set
+func passThreshold(quiz models.QuizJSON, course *models.Course) float64 {
+	if quiz.MinScore != nil {
+		return *quiz.MinScore
+	}
+	return course.DefaultMinScore
 }
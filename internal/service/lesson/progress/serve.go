@@ -0,0 +1,40 @@
+package progress
+
+import (
+	"SkillForge/internal/models"
+	"encoding/binary"
+	"github.com/google/uuid"
+	"math/rand/v2"
+	"slices"
+)
+
+// drawQuestions picks the questions of an attempt from the quiz pool and fixes the order of
+// questions and options, seeded by the attempt id. Ordering questions are always shuffled since
+// their stored order is the answer.
+func drawQuestions(quiz models.QuizJSON, attemptID uuid.UUID) []models.ServedQuestion {
+	rng := rand.New(rand.NewPCG(binary.BigEndian.Uint64(attemptID[:8]), binary.BigEndian.Uint64(attemptID[8:])))
+
+	indexes := rng.Perm(len(quiz.Questions))
+	if quiz.DrawCount > 0 && quiz.DrawCount < len(indexes) {
+		indexes = indexes[:quiz.DrawCount]
+	}
+	if !quiz.ShuffleQuestions {
+		slices.Sort(indexes)
+	}
+
+	served := make([]models.ServedQuestion, 0, len(indexes))
+	for _, i := range indexes {
+		question := quiz.Questions[i]
+		s := models.ServedQuestion{QuestionID: question.ID}
+		if quiz.ShuffleOptions || question.Type == models.QuestionTypeOrdering {
+			for _, option := range question.Options {
+				s.OptionIDs = append(s.OptionIDs, option.ID)
+			}
+			rng.Shuffle(len(s.OptionIDs), func(a, b int) {
+				s.OptionIDs[a], s.OptionIDs[b] = s.OptionIDs[b], s.OptionIDs[a]
+			})
+		}
+		served = append(served, s)
+	}
+	return served
+}
@@ -0,0 +1,374 @@
+package quizimport
+
+import (
+	"SkillForge/internal/models"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// escaped GIFT control characters are swapped for private use runes while the question is split up
+// and restored in the final texts
+var giftEscapes = strings.NewReplacer(
+	`\\`, "\uE000", `\~`, "\uE001", `\=`, "\uE002", `\#`, "\uE003",
+	`\{`, "\uE004", `\}`, "\uE005", `\:`, "\uE006", `\n`, "\n",
+)
+
+var giftUnescapes = strings.NewReplacer(
+	"\uE000", `\`, "\uE001", "~", "\uE002", "=", "\uE003", "#",
+	"\uE004", "{", "\uE005", "}", "\uE006", ":",
+)
+
+// giftItem is one question of a GIFT file before its answer block is interpreted
+type giftItem struct {
+	index int
+	name  string
+	html  bool
+	text  string
+	// answer is the content of the {} block, hasAnswer tells an empty block from a missing one
+	answer    string
+	hasAnswer bool
+	after     string
+}
+
+// parseGIFT reads questions separated by blank lines; comments and $CATEGORY lines are ignored
+func parseGIFT(data string) ([]models.QuizQuestion, []models.ImportIssue) {
+	data = strings.TrimPrefix(data, "\uFEFF")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+
+	var blocks []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+	for _, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "//"):
+			continue
+		case strings.HasPrefix(trimmed, "$CATEGORY"):
+			flush()
+			continue
+		case trimmed == "":
+			// a blank line inside an open answer block does not end the question
+			if open := strings.Join(current, "\n"); strings.Count(giftEscapes.Replace(open), "{") <= strings.Count(giftEscapes.Replace(open), "}") {
+				flush()
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+
+	var questions []models.QuizQuestion
+	var issues []models.ImportIssue
+	for i, block := range blocks {
+		item, err := splitGIFT(i+1, block)
+		if err != nil {
+			issues = append(issues, skipped(i+1, "", "", "%s", err.Error()))
+			continue
+		}
+		question, itemIssues, ok := item.question()
+		issues = append(issues, itemIssues...)
+		if ok {
+			questions = append(questions, question)
+		}
+	}
+	return questions, issues
+}
+
+func splitGIFT(index int, block string) (giftItem, error) {
+	item := giftItem{index: index}
+	s := strings.TrimSpace(giftEscapes.Replace(block))
+	if strings.HasPrefix(s, "::") {
+		end := strings.Index(s[2:], "::")
+		if end < 0 {
+			return item, fmt.Errorf("question title is not closed with ::")
+		}
+		item.name = strings.TrimSpace(giftUnescapes.Replace(s[2 : 2+end]))
+		s = strings.TrimSpace(s[4+end:])
+	}
+	if strings.HasPrefix(s, "[") {
+		if end := strings.Index(s, "]"); end > 0 {
+			item.html = strings.EqualFold(s[1:end], "html")
+			s = strings.TrimSpace(s[end+1:])
+		}
+	}
+
+	open := strings.Index(s, "{")
+	if open < 0 {
+		item.text = s
+		return item, nil
+	}
+	closing := strings.Index(s[open:], "}")
+	if closing < 0 {
+		return item, fmt.Errorf("answer block is not closed with }")
+	}
+	item.text = strings.TrimSpace(s[:open])
+	item.hasAnswer = true
+	item.answer = strings.TrimSpace(s[open+1 : open+closing])
+	item.after = strings.TrimSpace(s[open+closing+1:])
+	return item, nil
+}
+
+// giftAnswer is one entry of an answer block: =right, ~wrong, ~%50%partly right, each with #feedback
+type giftAnswer struct {
+	correct  bool
+	weight   float64
+	text     string
+	feedback string
+}
+
+func (item giftItem) question() (models.QuizQuestion, []models.ImportIssue, bool) {
+	// a question written only as ::title:: {answers} is asked by its title
+	if item.text == "" && item.after == "" {
+		item.text = item.name
+	}
+	question := models.QuizQuestion{Text: item.format(item.text), Required: true}
+	body, general, _ := strings.Cut(item.answer, "####")
+	question.Explanation = item.format(general)
+	body = strings.TrimSpace(body)
+
+	switch {
+	case !item.hasAnswer:
+		return question, []models.ImportIssue{skipped(item.index, item.name, "description", "description items have no answer and are not imported")}, false
+	case body == "":
+		return question, []models.ImportIssue{skipped(item.index, item.name, "essay", "essay questions need manual grading, use an assignment instead")}, false
+	case strings.HasPrefix(body, "#"):
+		return item.numeric(question, strings.TrimSpace(body[1:]))
+	}
+
+	first, _, _ := strings.Cut(body, "#")
+	switch strings.ToUpper(strings.TrimSpace(first)) {
+	case "T", "TRUE", "F", "FALSE":
+		return item.trueFalse(question, body)
+	}
+
+	answers, ok := splitGIFTAnswers(body)
+	if !ok {
+		return question, []models.ImportIssue{skipped(item.index, item.name, "", "answers must start with = or ~")}, false
+	}
+	hasWrong, isMatching := false, false
+	for _, answer := range answers {
+		hasWrong = hasWrong || !answer.correct
+		isMatching = isMatching || strings.Contains(answer.text, "->")
+	}
+	switch {
+	case isMatching:
+		return item.matching(question, answers)
+	case !hasWrong:
+		return item.shortAnswer(question, answers)
+	default:
+		return item.choice(question, answers)
+	}
+}
+
+// format restores escaped characters and converts HTML text to plain text
+func (item giftItem) format(s string) string {
+	s = giftUnescapes.Replace(strings.TrimSpace(s))
+	if item.html {
+		return plainText(s)
+	}
+	return cleanText(s)
+}
+
+// questionText adds the blank of a missing word question, whose answer block sits inside the sentence
+func (item giftItem) questionText(blank string) string {
+	if item.after == "" {
+		return item.format(item.text)
+	}
+	return item.format(item.text + " " + blank + " " + item.after)
+}
+
+func splitGIFTAnswers(body string) ([]giftAnswer, bool) {
+	if body[0] != '=' && body[0] != '~' {
+		return nil, false
+	}
+	var answers []giftAnswer
+	start := 0
+	for i := 1; i <= len(body); i++ {
+		if i < len(body) && body[i] != '=' && body[i] != '~' {
+			continue
+		}
+		answers = append(answers, parseGIFTAnswer(body[start:i]))
+		start = i
+	}
+	return answers, true
+}
+
+func parseGIFTAnswer(raw string) giftAnswer {
+	answer := giftAnswer{correct: raw[0] == '='}
+	s := strings.TrimSpace(raw[1:])
+	if strings.HasPrefix(s, "%") {
+		if end := strings.Index(s[1:], "%"); end >= 0 {
+			if weight, err := strconv.ParseFloat(s[1:1+end], 64); err == nil {
+				answer.weight = weight
+				answer.correct = weight > 0
+			}
+			s = s[end+2:]
+		}
+	} else if answer.correct {
+		answer.weight = 100
+	}
+	answer.text, answer.feedback, _ = strings.Cut(s, "#")
+	answer.text = strings.TrimSpace(answer.text)
+	answer.feedback = strings.TrimSpace(answer.feedback)
+	return answer
+}
+
+func (item giftItem) trueFalse(question models.QuizQuestion, body string) (models.QuizQuestion, []models.ImportIssue, bool) {
+	value, feedback, _ := strings.Cut(body, "#")
+	isTrue := strings.HasPrefix(strings.ToUpper(strings.TrimSpace(value)), "T")
+	// GIFT feedback is "#shown for a wrong answer#shown for the right one"
+	wrongFeedback, rightFeedback, _ := strings.Cut(feedback, "#")
+
+	question.Text = item.questionText("___")
+	question.Type = models.QuestionTypeSingle
+	question.Options = []models.QuizOption{
+		{Text: "True", IsCorrect: isTrue},
+		{Text: "False", IsCorrect: !isTrue},
+	}
+	for i := range question.Options {
+		if question.Options[i].IsCorrect {
+			question.Options[i].Explanation = item.format(rightFeedback)
+		} else {
+			question.Options[i].Explanation = item.format(wrongFeedback)
+		}
+	}
+	return question, nil, true
+}
+
+func (item giftItem) choice(question models.QuizQuestion, answers []giftAnswer) (models.QuizQuestion, []models.ImportIssue, bool) {
+	question.Text = item.questionText("___")
+	correct, negative, partial := 0, false, false
+	for _, answer := range answers {
+		question.Options = append(question.Options, models.QuizOption{
+			Text:        item.format(answer.text),
+			IsCorrect:   answer.correct,
+			Explanation: item.format(answer.feedback),
+		})
+		if answer.correct {
+			correct++
+		}
+		negative = negative || answer.weight < 0
+		partial = partial || (answer.correct && answer.weight < 100)
+	}
+
+	var issues []models.ImportIssue
+	question.Type = models.QuestionTypeSingle
+	if correct > 1 || partial {
+		question.Type = models.QuestionTypeMultiple
+		question.Scoring = models.ScoringProportional
+		if negative {
+			question.Scoring = models.ScoringRightMinusWrong
+		}
+		if correct == 1 {
+			issues = append(issues, warning(item.index, item.name, "multichoice", "the only partly weighted answer is imported as fully correct"))
+		}
+	}
+	return question, issues, true
+}
+
+func (item giftItem) shortAnswer(question models.QuizQuestion, answers []giftAnswer) (models.QuizQuestion, []models.ImportIssue, bool) {
+	var issues []models.ImportIssue
+	var accepted []string
+	for _, answer := range answers {
+		if answer.weight < 100 {
+			issues = append(issues, warning(item.index, item.name, "shortanswer",
+				"answer %q worth %g%% is dropped, only fully correct answers are imported", item.format(answer.text), answer.weight))
+			continue
+		}
+		accepted = append(accepted, item.format(answer.text))
+	}
+	if len(accepted) == 0 {
+		return question, append(issues, skipped(item.index, item.name, "shortanswer", "no fully correct answer")), false
+	}
+
+	if item.after != "" {
+		question.Type = models.QuestionTypeCloze
+		question.Text = item.questionText("{{b1}}")
+		question.Blanks = []models.QuizBlank{{ID: "b1", AcceptedAnswers: accepted}}
+		return question, issues, true
+	}
+	question.Type = models.QuestionTypeText
+	question.AcceptedAnswers = accepted
+	return question, issues, true
+}
+
+func (item giftItem) matching(question models.QuizQuestion, answers []giftAnswer) (models.QuizQuestion, []models.ImportIssue, bool) {
+	var issues []models.ImportIssue
+	question.Type = models.QuestionTypeMatching
+	question.Scoring = models.ScoringProportional
+	for _, answer := range answers {
+		prompt, match, ok := strings.Cut(answer.text, "->")
+		prompt, match = item.format(prompt), item.format(match)
+		if !ok || !answer.correct || match == "" {
+			return question, []models.ImportIssue{skipped(item.index, item.name, "matching", "matching pairs must be written as =prompt -> match")}, false
+		}
+		if prompt == "" {
+			issues = append(issues, warning(item.index, item.name, "matching", "extra match %q without a prompt is dropped", match))
+			continue
+		}
+		question.Pairs = append(question.Pairs, models.QuizPair{Prompt: prompt, Match: match})
+	}
+	return question, issues, true
+}
+
+// numeric accepts "value", "value:tolerance" and "min..max", or several =answers of which the fully correct one is kept
+func (item giftItem) numeric(question models.QuizQuestion, body string) (models.QuizQuestion, []models.ImportIssue, bool) {
+	question.Type = models.QuestionTypeNumeric
+	question.Text = item.questionText("___")
+	var issues []models.ImportIssue
+
+	spec := body
+	if strings.HasPrefix(body, "=") {
+		answers, _ := splitGIFTAnswers(body)
+		spec = ""
+		for _, answer := range answers {
+			if answer.weight >= 100 && spec == "" {
+				spec = answer.text
+				continue
+			}
+			issues = append(issues, warning(item.index, item.name, "numerical",
+				"answer %q worth %g%% is dropped, only one fully correct answer is imported", answer.text, answer.weight))
+		}
+	} else {
+		var feedback string
+		spec, feedback, _ = strings.Cut(body, "#")
+		question.Explanation = strings.TrimSpace(strings.Join([]string{item.format(feedback), question.Explanation}, "\n\n"))
+	}
+
+	value, tolerance, err := numericRange(strings.TrimSpace(spec))
+	if err != nil {
+		return question, append(issues, skipped(item.index, item.name, "numerical", "%s", err.Error())), false
+	}
+	question.NumericAnswer = &value
+	question.Tolerance = tolerance
+	return question, issues, true
+}
+
+func numericRange(spec string) (float64, float64, error) {
+	if low, high, ok := strings.Cut(spec, ".."); ok {
+		min, err1 := strconv.ParseFloat(strings.TrimSpace(low), 64)
+		max, err2 := strconv.ParseFloat(strings.TrimSpace(high), 64)
+		if err1 != nil || err2 != nil || max < min {
+			return 0, 0, fmt.Errorf("invalid numeric range %q", spec)
+		}
+		return (min + max) / 2, (max - min) / 2, nil
+	}
+	value, tol, _ := strings.Cut(giftUnescapes.Replace(spec), ":")
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid numeric answer %q", spec)
+	}
+	tolerance := 0.0
+	if strings.TrimSpace(tol) != "" {
+		if tolerance, err = strconv.ParseFloat(strings.TrimSpace(tol), 64); err != nil || tolerance < 0 {
+			return 0, 0, fmt.Errorf("invalid numeric tolerance %q", spec)
+		}
+	}
+	return number, tolerance, nil
+}
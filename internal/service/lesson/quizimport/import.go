@@ -0,0 +1,136 @@
+// Package quizimport converts quizzes exported from other LMSs (GIFT, Moodle XML and IMS QTI 2.1)
+// into the quiz format of lessons.
+package quizimport
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"bytes"
+	"fmt"
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Parse converts the file into a quiz. Items that cannot be represented are skipped and reported
+// as issues; an error means the file itself could not be read.
+func Parse(format string, data []byte) (models.QuizJSON, []models.ImportIssue, error) {
+	var (
+		questions []models.QuizQuestion
+		issues    []models.ImportIssue
+		err       error
+	)
+	switch format {
+	case models.ImportFormatGIFT:
+		questions, issues = parseGIFT(string(data))
+	case models.ImportFormatMoodleXML:
+		questions, issues, err = parseMoodle(data)
+	case models.ImportFormatQTI:
+		questions, issues, err = parseQTI(data)
+	default:
+		return models.QuizJSON{}, nil, app_errors.ErrUnknownImportFormat
+	}
+	if err != nil {
+		return models.QuizJSON{}, nil, fmt.Errorf("%w: %s", app_errors.ErrInvalidImportFile, err.Error())
+	}
+	if questions == nil {
+		questions = []models.QuizQuestion{}
+	}
+	assignIDs(questions)
+	if issues == nil {
+		issues = []models.ImportIssue{}
+	}
+	return models.QuizJSON{Version: models.QuizSchemaVersion, Questions: questions}, issues, nil
+}
+
+// Detect guesses the format from the file name and falls back to sniffing the content
+func Detect(filename string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".gift", ".txt":
+		return models.ImportFormatGIFT
+	case ".zip":
+		return models.ImportFormatQTI
+	}
+	head := data
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")), bytes.Contains(head, []byte("assessmentItem")):
+		return models.ImportFormatQTI
+	case bytes.Contains(head, []byte("<quiz")):
+		return models.ImportFormatMoodleXML
+	case bytes.HasPrefix(bytes.TrimSpace(head), []byte("<")):
+		return ""
+	}
+	return models.ImportFormatGIFT
+}
+
+var (
+	tagRe        = regexp.MustCompile(`(?s)<[^>]*>`)
+	blockTagRe   = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/h\d)\b[^>]*>`)
+	spaceRe      = regexp.MustCompile(`[ \t\f\r]+`)
+	emptyLinesRe = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// plainText turns the HTML that LMSs store question text in into plain text, keeping paragraph breaks
+func plainText(s string) string {
+	s = blockTagRe.ReplaceAllString(s, "\n")
+	s = tagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	return cleanText(s)
+}
+
+// cleanText collapses runs of spaces and blank lines
+func cleanText(s string) string {
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	s = spaceRe.ReplaceAllString(s, " ")
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	s = strings.Join(lines, "\n")
+	s = emptyLinesRe.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+func skipped(item int, name, itemType, format string, args ...any) models.ImportIssue {
+	return models.ImportIssue{Item: item, Name: name, Type: itemType, Message: fmt.Sprintf(format, args...), Skipped: true}
+}
+
+func warning(item int, name, itemType, format string, args ...any) models.ImportIssue {
+	return models.ImportIssue{Item: item, Name: name, Type: itemType, Message: fmt.Sprintf(format, args...)}
+}
+
+// keepOneCorrect leaves only the option at keep correct: single choice questions accept exactly one right answer.
+// It returns a warning when other options lost their credit.
+func keepOneCorrect(options []models.QuizOption, keep int) string {
+	dropped := 0
+	for i := range options {
+		if i != keep && options[i].IsCorrect {
+			options[i].IsCorrect = false
+			dropped++
+		}
+	}
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf("several options give credit, only %q is kept as the correct one", options[keep].Text)
+}
+
+// assignIDs gives options, pairs and matches random ids. Learners see them, so ids numbered in source order
+// or taken from the file would give away ordering and matching answers.
+func assignIDs(questions []models.QuizQuestion) {
+	for i := range questions {
+		for j := range questions[i].Options {
+			questions[i].Options[j].ID = uuid.NewString()
+		}
+		for j := range questions[i].Pairs {
+			questions[i].Pairs[j].ID = uuid.NewString()
+			questions[i].Pairs[j].MatchID = uuid.NewString()
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package quizimport
+
+import (
+	"SkillForge/internal/models"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type moodleQuiz struct {
+	Questions []moodleQuestion `xml:"question"`
+}
+
+type moodleQuestion struct {
+	Type            string              `xml:"type,attr"`
+	Name            moodleText          `xml:"name"`
+	QuestionText    moodleText          `xml:"questiontext"`
+	GeneralFeedback moodleText          `xml:"generalfeedback"`
+	DefaultGrade    string              `xml:"defaultgrade"`
+	Single          string              `xml:"single"`
+	UseCase         string              `xml:"usecase"`
+	Answers         []moodleAnswer      `xml:"answer"`
+	SubQuestions    []moodleSubquestion `xml:"subquestion"`
+	Units           []moodleUnit        `xml:"units>unit"`
+}
+
+type moodleText struct {
+	Format string `xml:"format,attr"`
+	Text   string `xml:"text"`
+}
+
+// plain returns the text without markup; Moodle stores rich text as HTML unless the format says otherwise
+func (t moodleText) plain() string {
+	switch t.Format {
+	case "plain_text", "markdown":
+		return cleanText(t.Text)
+	}
+	return plainText(t.Text)
+}
+
+type moodleAnswer struct {
+	Fraction  string     `xml:"fraction,attr"`
+	Format    string     `xml:"format,attr"`
+	Text      string     `xml:"text"`
+	Feedback  moodleText `xml:"feedback"`
+	Tolerance string     `xml:"tolerance"`
+}
+
+func (a moodleAnswer) plain() string {
+	return moodleText{Format: a.Format, Text: a.Text}.plain()
+}
+
+func (a moodleAnswer) fraction() float64 {
+	fraction, _ := strconv.ParseFloat(strings.TrimSpace(a.Fraction), 64)
+	return fraction
+}
+
+type moodleSubquestion struct {
+	Format string `xml:"format,attr"`
+	Text   string `xml:"text"`
+	Answer struct {
+		Text string `xml:"text"`
+	} `xml:"answer"`
+}
+
+type moodleUnit struct {
+	Multiplier string `xml:"multiplier"`
+	Name       string `xml:"unit_name"`
+}
+
+// question types Moodle can export that have no counterpart in lesson quizzes
+var moodleUnsupported = map[string]string{
+	"essay":             "essay questions need manual grading, use an assignment instead",
+	"multianswer":       "embedded answers (Cloze) questions are not supported",
+	"calculated":        "calculated questions are not supported",
+	"calculatedsimple":  "calculated questions are not supported",
+	"calculatedmulti":   "calculated questions are not supported",
+	"randomsamatch":     "random short-answer matching questions are not supported",
+	"ddwtos":            "drag and drop into text questions are not supported",
+	"ddimageortext":     "drag and drop onto image questions are not supported",
+	"ddmarker":          "drag and drop markers questions are not supported",
+	"gapselect":         "select missing words questions are not supported",
+	"random":            "random questions are not supported",
+	"description":       "description items have no answer and are not imported",
+	"shortanswer_regex": "regular expression short answers are not supported",
+}
+
+func parseMoodle(data []byte) ([]models.QuizQuestion, []models.ImportIssue, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	var quiz moodleQuiz
+	if err := decoder.Decode(&quiz); err != nil {
+		return nil, nil, err
+	}
+
+	var questions []models.QuizQuestion
+	var issues []models.ImportIssue
+	item := 0
+	for _, mq := range quiz.Questions {
+		// categories are question bank folders, not questions
+		if mq.Type == "category" {
+			continue
+		}
+		item++
+		name := strings.TrimSpace(mq.Name.Text)
+		if reason, ok := moodleUnsupported[mq.Type]; ok {
+			issues = append(issues, skipped(item, name, mq.Type, "%s", reason))
+			continue
+		}
+
+		question := models.QuizQuestion{
+			Text:        mq.QuestionText.plain(),
+			Required:    true,
+			Explanation: mq.GeneralFeedback.plain(),
+		}
+		if grade, err := strconv.ParseFloat(strings.TrimSpace(mq.DefaultGrade), 64); err == nil && grade > 0 {
+			question.Points = grade
+		}
+
+		var itemIssues []string
+		var err error
+		switch mq.Type {
+		case "multichoice":
+			itemIssues = moodleChoice(&question, mq)
+		case "truefalse":
+			itemIssues = moodleChoice(&question, mq)
+			question.Type = models.QuestionTypeSingle
+		case "shortanswer":
+			itemIssues, err = moodleShortAnswer(&question, mq)
+		case "numerical":
+			itemIssues, err = moodleNumerical(&question, mq)
+		case "match":
+			itemIssues, err = moodleMatching(&question, mq)
+		case "ordering":
+			question.Type = models.QuestionTypeOrdering
+			question.Scoring = models.ScoringProportional
+			for _, answer := range mq.Answers {
+				question.Options = append(question.Options, models.QuizOption{Text: answer.plain()})
+			}
+		default:
+			err = fmt.Errorf("question type %q is not supported", mq.Type)
+		}
+		if err != nil {
+			issues = append(issues, skipped(item, name, mq.Type, "%s", err.Error()))
+			continue
+		}
+		for _, message := range itemIssues {
+			issues = append(issues, warning(item, name, mq.Type, "%s", message))
+		}
+		questions = append(questions, question)
+	}
+	return questions, issues, nil
+}
+
+// moodleChoice maps answer fractions to correct options: a single-answer question has one correct option,
+// a multiple-answer one gets partial credit and right minus wrong scoring when wrong answers carry penalties
+func moodleChoice(question *models.QuizQuestion, mq moodleQuestion) []string {
+	var issues []string
+	correct, negative := 0, false
+	for _, answer := range mq.Answers {
+		fraction := answer.fraction()
+		question.Options = append(question.Options, models.QuizOption{
+			Text:        answer.plain(),
+			IsCorrect:   fraction > 0,
+			Explanation: answer.Feedback.plain(),
+		})
+		if fraction > 0 {
+			correct++
+		}
+		negative = negative || fraction < 0
+	}
+
+	if mq.Single == "false" || mq.Single == "0" {
+		question.Type = models.QuestionTypeMultiple
+		question.Scoring = models.ScoringProportional
+		if negative {
+			question.Scoring = models.ScoringRightMinusWrong
+		}
+		return issues
+	}
+	question.Type = models.QuestionTypeSingle
+	best := -1
+	for i, answer := range mq.Answers {
+		if best < 0 || answer.fraction() > mq.Answers[best].fraction() {
+			best = i
+		}
+	}
+	if best < 0 || correct == 0 {
+		return issues
+	}
+	if fraction := mq.Answers[best].fraction(); fraction < 100 {
+		issues = append(issues, fmt.Sprintf("option %q worth %g%% is imported as fully correct", question.Options[best].Text, fraction))
+	}
+	if message := keepOneCorrect(question.Options, best); message != "" {
+		issues = append(issues, message)
+	}
+	return issues
+}
+
+func moodleShortAnswer(question *models.QuizQuestion, mq moodleQuestion) ([]string, error) {
+	var issues []string
+	question.Type = models.QuestionTypeText
+	for _, answer := range mq.Answers {
+		text := answer.plain()
+		fraction := answer.fraction()
+		switch {
+		case fraction >= 100 && strings.Contains(text, "*"):
+			// Moodle's * wildcard matches any characters; the grader anchors the pattern itself
+			parts := strings.Split(text, "*")
+			for i := range parts {
+				parts[i] = regexp.QuoteMeta(parts[i])
+			}
+			pattern := strings.Join(parts, ".*")
+			if mq.UseCase != "1" {
+				pattern = "(?i:" + pattern + ")"
+			}
+			if question.Pattern != "" {
+				pattern = question.Pattern + "|" + pattern
+			}
+			question.Pattern = pattern
+		case fraction >= 100:
+			question.AcceptedAnswers = append(question.AcceptedAnswers, text)
+		case fraction > 0:
+			issues = append(issues, fmt.Sprintf("answer %q worth %g%% is dropped, only fully correct answers are imported", text, fraction))
+		}
+	}
+	if len(question.AcceptedAnswers) == 0 && question.Pattern == "" {
+		return nil, fmt.Errorf("no fully correct answer")
+	}
+	if mq.UseCase == "1" && len(question.AcceptedAnswers) > 0 {
+		issues = append(issues, "case-sensitive answers are compared ignoring case")
+	}
+	return issues, nil
+}
+
+func moodleNumerical(question *models.QuizQuestion, mq moodleQuestion) ([]string, error) {
+	var issues []string
+	question.Type = models.QuestionTypeNumeric
+	for _, answer := range mq.Answers {
+		fraction := answer.fraction()
+		if fraction < 100 {
+			if fraction > 0 {
+				issues = append(issues, fmt.Sprintf("answer %q worth %g%% is dropped, only fully correct answers are imported", answer.plain(), fraction))
+			}
+			continue
+		}
+		if question.NumericAnswer != nil {
+			issues = append(issues, fmt.Sprintf("extra correct answer %q is dropped", answer.plain()))
+			continue
+		}
+		value, err := strconv.ParseFloat(answer.plain(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid numeric answer %q", answer.plain())
+		}
+		question.NumericAnswer = &value
+		if tolerance, err := strconv.ParseFloat(strings.TrimSpace(answer.Tolerance), 64); err == nil && tolerance > 0 {
+			question.Tolerance = tolerance
+		}
+	}
+	if question.NumericAnswer == nil {
+		return nil, fmt.Errorf("no fully correct answer")
+	}
+	for _, unit := range mq.Units {
+		multiplier, err := strconv.ParseFloat(strings.TrimSpace(unit.Multiplier), 64)
+		if err == nil && multiplier != 1 {
+			issues = append(issues, fmt.Sprintf("unit %q with multiplier %g is dropped, only the base unit is imported", unit.Name, multiplier))
+			continue
+		}
+		if name := strings.TrimSpace(unit.Name); name != "" {
+			question.Units = append(question.Units, name)
+		}
+	}
+	return issues, nil
+}
+
+func moodleMatching(question *models.QuizQuestion, mq moodleQuestion) ([]string, error) {
+	var issues []string
+	question.Type = models.QuestionTypeMatching
+	question.Scoring = models.ScoringProportional
+	for _, sub := range mq.SubQuestions {
+		prompt := moodleText{Format: sub.Format, Text: sub.Text}.plain()
+		match := cleanText(sub.Answer.Text)
+		// subquestions without a prompt only add wrong choices to the match list
+		if prompt == "" {
+			issues = append(issues, fmt.Sprintf("extra match %q without a prompt is dropped", match))
+			continue
+		}
+		question.Pairs = append(question.Pairs, models.QuizPair{Prompt: prompt, Match: match})
+	}
+	if len(question.Pairs) < 2 {
+		return nil, fmt.Errorf("matching question needs at least 2 pairs")
+	}
+	return issues, nil
+}
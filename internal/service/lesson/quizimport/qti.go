@@ -0,0 +1,458 @@
+package quizimport
+
+import (
+	"SkillForge/internal/models"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// a QTI package is read fully into memory, so its entries are capped like the upload itself and the package
+// as a whole against archives that unpack into far more than was uploaded
+const (
+	maxQTIEntrySize   = 10 << 20
+	maxQTIPackageSize = 50 << 20
+	maxQTIEntries     = 1000
+)
+
+var qtiInteractions = map[string]bool{
+	"choiceInteraction": true, "orderInteraction": true, "matchInteraction": true, "textEntryInteraction": true,
+	"associateInteraction": true, "gapMatchInteraction": true, "inlineChoiceInteraction": true,
+	"extendedTextInteraction": true, "hottextInteraction": true, "hotspotInteraction": true,
+	"selectPointInteraction": true, "graphicOrderInteraction": true, "graphicAssociateInteraction": true,
+	"graphicGapMatchInteraction": true, "positionObjectInteraction": true, "sliderInteraction": true,
+	"uploadInteraction": true, "drawingInteraction": true, "mediaInteraction": true, "customInteraction": true,
+	"endAttemptInteraction": true,
+}
+
+var blankIDRe = regexp.MustCompile(`[^\w-]+`)
+
+// parseQTI reads a single assessmentItem or a content package zip holding one item per file
+func parseQTI(data []byte) ([]models.QuizQuestion, []models.ImportIssue, error) {
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		root, err := parseXML(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		items := root.findAll("assessmentItem")
+		if len(items) == 0 {
+			return nil, nil, fmt.Errorf("no assessmentItem found")
+		}
+		return qtiItems(items, nil)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(archive.File) > maxQTIEntries {
+		return nil, nil, fmt.Errorf("the package has more than %d files", maxQTIEntries)
+	}
+	var items []*node
+	var files []string
+	var unpacked int64
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(path.Ext(file.Name), ".xml") || strings.EqualFold(path.Base(file.Name), "imsmanifest.xml") {
+			continue
+		}
+		if file.UncompressedSize64 > maxQTIEntrySize {
+			return nil, nil, fmt.Errorf("%s is too large", file.Name)
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		// the sizes in the zip headers are not trusted, the bytes actually read are counted
+		limit := min(maxQTIEntrySize, maxQTIPackageSize-unpacked)
+		content, err := io.ReadAll(io.LimitReader(reader, limit+1))
+		reader.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		if int64(len(content)) > limit {
+			if limit < maxQTIEntrySize {
+				return nil, nil, fmt.Errorf("the package unpacks to more than %d MB", maxQTIPackageSize>>20)
+			}
+			return nil, nil, fmt.Errorf("%s is too large", file.Name)
+		}
+		unpacked += int64(len(content))
+		root, err := parseXML(content)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		// tests and other package resources only reference the items
+		for _, item := range root.findAll("assessmentItem") {
+			items = append(items, item)
+			files = append(files, path.Base(file.Name))
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil, fmt.Errorf("the package has no assessment items")
+	}
+	return qtiItems(items, files)
+}
+
+func qtiItems(items []*node, files []string) ([]models.QuizQuestion, []models.ImportIssue, error) {
+	var questions []models.QuizQuestion
+	var issues []models.ImportIssue
+	for i, item := range items {
+		name := item.attr("title")
+		if name == "" && files != nil {
+			name = files[i]
+		}
+		question, itemType, messages, err := qtiQuestion(item)
+		if err != nil {
+			issues = append(issues, skipped(i+1, name, itemType, "%s", err.Error()))
+			continue
+		}
+		for _, message := range messages {
+			issues = append(issues, warning(i+1, name, itemType, "%s", message))
+		}
+		questions = append(questions, question)
+	}
+	return questions, issues, nil
+}
+
+func qtiQuestion(item *node) (models.QuizQuestion, string, []string, error) {
+	question := models.QuizQuestion{Required: true}
+	body := item.child("itemBody")
+	if body == nil {
+		return question, "", nil, fmt.Errorf("item has no itemBody")
+	}
+
+	var interactions []*node
+	collectInteractions(body, &interactions)
+	if len(interactions) == 0 {
+		return question, "", nil, fmt.Errorf("item has no interaction")
+	}
+	itemType := interactions[0].name
+	for _, interaction := range interactions[1:] {
+		if interaction.name != "textEntryInteraction" || itemType != "textEntryInteraction" {
+			return question, "composite", nil, fmt.Errorf("items combining several interactions are not supported")
+		}
+	}
+
+	for _, outcome := range item.childrenNamed("outcomeDeclaration") {
+		if outcome.attr("identifier") != "MAXSCORE" {
+			continue
+		}
+		if value, err := strconv.ParseFloat(strings.TrimSpace(outcome.find("value").innerText()), 64); err == nil && value > 0 {
+			question.Points = value
+		}
+	}
+	var feedback []string
+	for _, modal := range item.childrenNamed("modalFeedback") {
+		if text := qtiText(modal, nil); text != "" {
+			feedback = append(feedback, text)
+		}
+	}
+	question.Explanation = strings.Join(feedback, "\n\n")
+
+	var (
+		messages []string
+		err      error
+	)
+	switch itemType {
+	case "choiceInteraction":
+		question.Text = qtiPromptText(body)
+		messages, err = qtiChoice(&question, item, interactions[0])
+	case "orderInteraction":
+		question.Text = qtiPromptText(body)
+		err = qtiOrder(&question, item, interactions[0])
+	case "matchInteraction":
+		question.Text = qtiPromptText(body)
+		messages, err = qtiMatch(&question, item, interactions[0])
+	case "textEntryInteraction":
+		messages, err = qtiTextEntry(&question, item, body, interactions)
+	default:
+		err = fmt.Errorf("%s is not supported", itemType)
+	}
+	return question, itemType, messages, err
+}
+
+func collectInteractions(n *node, interactions *[]*node) {
+	for _, c := range n.children {
+		if qtiInteractions[c.name] {
+			*interactions = append(*interactions, c)
+			continue
+		}
+		collectInteractions(c, interactions)
+	}
+}
+
+// qtiText renders the element without inline feedback, which is shown only after answering
+func qtiText(n *node, replace func(*node) (string, bool)) string {
+	var b strings.Builder
+	n.render(&b, func(c *node) (string, bool) {
+		switch c.name {
+		case "feedbackInline", "feedbackBlock", "rubricBlock", "templateBlock", "templateInline":
+			return "", true
+		}
+		if replace != nil {
+			return replace(c)
+		}
+		return "", false
+	})
+	return cleanText(b.String())
+}
+
+// qtiPromptText is the item body with the interaction replaced by its prompt
+func qtiPromptText(body *node) string {
+	return qtiText(body, func(c *node) (string, bool) {
+		if !qtiInteractions[c.name] {
+			return "", false
+		}
+		if prompt := c.child("prompt"); prompt != nil {
+			return "\n" + qtiText(prompt, nil) + "\n", true
+		}
+		return "", true
+	})
+}
+
+// qtiResponse returns the declaration of the interaction's response, its correct values and mapping
+func qtiResponse(item, interaction *node) (declaration *node, correct []string, mapping map[string]float64) {
+	identifier := interaction.attr("responseIdentifier")
+	for _, d := range item.childrenNamed("responseDeclaration") {
+		if d.attr("identifier") == identifier {
+			declaration = d
+			break
+		}
+	}
+	if declaration == nil {
+		return nil, nil, nil
+	}
+	if cr := declaration.child("correctResponse"); cr != nil {
+		for _, value := range cr.childrenNamed("value") {
+			correct = append(correct, strings.TrimSpace(value.innerText()))
+		}
+	}
+	if m := declaration.child("mapping"); m != nil {
+		mapping = make(map[string]float64)
+		for _, entry := range m.childrenNamed("mapEntry") {
+			value, _ := strconv.ParseFloat(entry.attr("mappedValue"), 64)
+			mapping[entry.attr("mapKey")] = value
+		}
+	}
+	return declaration, correct, mapping
+}
+
+func qtiChoice(question *models.QuizQuestion, item, interaction *node) ([]string, error) {
+	declaration, correct, mapping := qtiResponse(item, interaction)
+	if declaration == nil {
+		return nil, fmt.Errorf("response %q is not declared", interaction.attr("responseIdentifier"))
+	}
+	isCorrect := make(map[string]bool, len(correct))
+	for _, id := range correct {
+		isCorrect[id] = true
+	}
+	negative := false
+	for key, value := range mapping {
+		if value > 0 && len(correct) == 0 {
+			isCorrect[key] = true
+		}
+		negative = negative || value < 0
+	}
+
+	for _, choice := range interaction.childrenNamed("simpleChoice") {
+		id := choice.attr("identifier")
+		var explanation []string
+		for _, fb := range choice.findAll("feedbackInline") {
+			explanation = append(explanation, qtiText(fb, nil))
+		}
+		question.Options = append(question.Options, models.QuizOption{
+			ID:          id,
+			Text:        qtiText(choice, nil),
+			IsCorrect:   isCorrect[id],
+			Explanation: strings.Join(explanation, " "),
+		})
+	}
+
+	question.Type = models.QuestionTypeSingle
+	if declaration.attr("cardinality") != "single" {
+		question.Type = models.QuestionTypeMultiple
+		question.Scoring = models.ScoringProportional
+		if negative {
+			question.Scoring = models.ScoringRightMinusWrong
+		}
+	}
+	var messages []string
+	if question.Type == models.QuestionTypeSingle {
+		for i, option := range question.Options {
+			if option.IsCorrect {
+				if message := keepOneCorrect(question.Options, i); message != "" {
+					messages = append(messages, message)
+				}
+				break
+			}
+		}
+	}
+	return messages, nil
+}
+
+func qtiOrder(question *models.QuizQuestion, item, interaction *node) error {
+	_, correct, _ := qtiResponse(item, interaction)
+	choices := make(map[string]string)
+	for _, choice := range interaction.childrenNamed("simpleChoice") {
+		choices[choice.attr("identifier")] = qtiText(choice, nil)
+	}
+	if len(correct) != len(choices) {
+		return fmt.Errorf("the correct order must list every choice once")
+	}
+	question.Type = models.QuestionTypeOrdering
+	question.Scoring = models.ScoringProportional
+	for _, id := range correct {
+		text, ok := choices[id]
+		if !ok {
+			return fmt.Errorf("the correct order refers to unknown choice %q", id)
+		}
+		delete(choices, id)
+		question.Options = append(question.Options, models.QuizOption{ID: id, Text: text})
+	}
+	return nil
+}
+
+func qtiMatch(question *models.QuizQuestion, item, interaction *node) ([]string, error) {
+	_, correct, mapping := qtiResponse(item, interaction)
+	sets := interaction.childrenNamed("simpleMatchSet")
+	if len(sets) != 2 {
+		return nil, fmt.Errorf("match interaction must have two match sets")
+	}
+	if len(correct) == 0 {
+		for key, value := range mapping {
+			if value > 0 {
+				correct = append(correct, key)
+			}
+		}
+	}
+	texts := func(set *node) map[string]string {
+		byID := make(map[string]string)
+		for _, choice := range set.childrenNamed("simpleAssociableChoice") {
+			byID[choice.attr("identifier")] = qtiText(choice, nil)
+		}
+		return byID
+	}
+	prompts, matches := texts(sets[0]), texts(sets[1])
+
+	question.Type = models.QuestionTypeMatching
+	question.Scoring = models.ScoringProportional
+	used := make(map[string]bool)
+	// keep the source order of the prompts rather than the order of the answer key
+	pairOf := make(map[string]string, len(correct))
+	for _, pair := range correct {
+		fields := strings.Fields(pair)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid directed pair %q", pair)
+		}
+		if _, ok := prompts[fields[0]]; !ok {
+			return nil, fmt.Errorf("directed pair %q does not start with a prompt of the first set", pair)
+		}
+		if _, ok := matches[fields[1]]; !ok {
+			return nil, fmt.Errorf("directed pair %q does not end with a choice of the second set", pair)
+		}
+		if used[fields[1]] || pairOf[fields[0]] != "" {
+			return nil, fmt.Errorf("pairs sharing a prompt or a match are not supported")
+		}
+		used[fields[1]] = true
+		pairOf[fields[0]] = fields[1]
+	}
+
+	var messages []string
+	for _, choice := range sets[0].childrenNamed("simpleAssociableChoice") {
+		id := choice.attr("identifier")
+		matchID, ok := pairOf[id]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("prompt %q has no correct match and is dropped", prompts[id]))
+			continue
+		}
+		question.Pairs = append(question.Pairs, models.QuizPair{ID: id, Prompt: prompts[id], MatchID: matchID, Match: matches[matchID]})
+	}
+	for _, choice := range sets[1].childrenNamed("simpleAssociableChoice") {
+		if id := choice.attr("identifier"); !used[id] {
+			messages = append(messages, fmt.Sprintf("extra match %q without a prompt is dropped", matches[id]))
+		}
+	}
+	return messages, nil
+}
+
+// qtiTextEntry turns a single text entry into a text or numeric question and several into a cloze question
+func qtiTextEntry(question *models.QuizQuestion, item, body *node, interactions []*node) ([]string, error) {
+	if len(interactions) == 1 {
+		declaration, correct, mapping := qtiResponse(item, interactions[0])
+		if declaration == nil {
+			return nil, fmt.Errorf("response %q is not declared", interactions[0].attr("responseIdentifier"))
+		}
+		question.Text = qtiText(body, func(c *node) (string, bool) {
+			return "___", c.name == "textEntryInteraction"
+		})
+		switch declaration.attr("baseType") {
+		case "float", "integer":
+			if len(correct) == 0 {
+				return nil, fmt.Errorf("numeric response has no correct value")
+			}
+			value, err := strconv.ParseFloat(correct[0], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid numeric answer %q", correct[0])
+			}
+			question.Type = models.QuestionTypeNumeric
+			question.NumericAnswer = &value
+			return nil, nil
+		}
+		question.Type = models.QuestionTypeText
+		question.AcceptedAnswers = acceptedAnswers(correct, mapping)
+		if len(question.AcceptedAnswers) == 0 {
+			return nil, fmt.Errorf("text response has no correct value")
+		}
+		return nil, nil
+	}
+
+	blankIDs := make(map[*node]string, len(interactions))
+	question.Type = models.QuestionTypeCloze
+	question.Scoring = models.ScoringProportional
+	for _, interaction := range interactions {
+		_, correct, mapping := qtiResponse(item, interaction)
+		id := blankIDRe.ReplaceAllString(interaction.attr("responseIdentifier"), "_")
+		blank := models.QuizBlank{ID: id, AcceptedAnswers: acceptedAnswers(correct, mapping)}
+		if len(blank.AcceptedAnswers) == 0 {
+			return nil, fmt.Errorf("blank %q has no correct value", id)
+		}
+		blankIDs[interaction] = id
+		question.Blanks = append(question.Blanks, blank)
+	}
+	question.Text = qtiText(body, func(c *node) (string, bool) {
+		id, ok := blankIDs[c]
+		return "{{" + id + "}}", ok
+	})
+	return nil, nil
+}
+
+// acceptedAnswers joins the correct response with the mapped keys that earn credit
+func acceptedAnswers(correct []string, mapping map[string]float64) []string {
+	seen := make(map[string]bool)
+	var accepted []string
+	add := func(answer string) {
+		if answer != "" && !seen[answer] {
+			seen[answer] = true
+			accepted = append(accepted, answer)
+		}
+	}
+	for _, answer := range correct {
+		add(answer)
+	}
+	keys := make([]string, 0, len(mapping))
+	for key, value := range mapping {
+		if value > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key)
+	}
+	return accepted
+}
@@ -0,0 +1,137 @@
+package quizimport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// node is an XML element with its text and child elements kept in document order, which the QTI item
+// body needs to rebuild the question text around the interactions. Names are local, namespaces dropped.
+type node struct {
+	name     string
+	attrs    map[string]string
+	children []*node
+	text     string // set only on text nodes, which have no name
+}
+
+func parseXML(data []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	root := &node{name: "#document"}
+	stack := []*node{root}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &node{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr))}
+			for _, attr := range t.Attr {
+				element.attrs[attr.Name.Local] = attr.Value
+			}
+			parent.children = append(parent.children, element)
+			stack = append(stack, element)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			parent.children = append(parent.children, &node{text: string(t)})
+		}
+	}
+	return root, nil
+}
+
+func (n *node) attr(name string) string {
+	return n.attrs[name]
+}
+
+// child returns the first direct child element with the name
+func (n *node) child(name string) *node {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *node) childrenNamed(name string) []*node {
+	var found []*node
+	for _, c := range n.children {
+		if c.name == name {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// find returns the first descendant element with the name, depth first
+func (n *node) find(name string) *node {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+		if found := c.find(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func (n *node) findAll(name string) []*node {
+	var found []*node
+	for _, c := range n.children {
+		if c.name == name {
+			found = append(found, c)
+		}
+		found = append(found, c.findAll(name)...)
+	}
+	return found
+}
+
+// innerText concatenates all text below the element
+func (n *node) innerText() string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	n.render(&b, nil)
+	return b.String()
+}
+
+// render writes the text below the element; replace may substitute an element with its own text
+func (n *node) render(b *strings.Builder, replace func(*node) (string, bool)) {
+	for _, c := range n.children {
+		if c.name == "" {
+			b.WriteString(c.text)
+			continue
+		}
+		if replace != nil {
+			if text, ok := replace(c); ok {
+				b.WriteString(text)
+				continue
+			}
+		}
+		if blockElements[c.name] {
+			b.WriteString("\n")
+		}
+		c.render(b, replace)
+		if blockElements[c.name] {
+			b.WriteString("\n")
+		}
+	}
+}
+
+var blockElements = map[string]bool{"p": true, "div": true, "br": true, "li": true, "tr": true, "h1": true, "h2": true, "h3": true}
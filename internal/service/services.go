@@ -1,11 +1,14 @@
 package service
 
 import (
+	"SkillForge/internal/service/admin"
+	"SkillForge/internal/service/application"
 	"SkillForge/internal/service/auth"
 	cm "SkillForge/internal/service/course/management"
 	"SkillForge/internal/service/course/query"
 	"SkillForge/internal/service/course/rating"
 	"SkillForge/internal/service/course/subscription"
+	"SkillForge/internal/service/lesson/assignment"
 	"SkillForge/internal/service/lesson/content"
 	"SkillForge/internal/service/lesson/progress"
 
@@ -23,4 +26,8 @@ type Collection struct {
 	*lm.LessonManagementService
 	*content.LessonContentService
 	*progress.LessonProgressService
+	*assignment.AssignmentService
+
+	*admin.AdminService
+	*application.AuthorApplicationService
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/google/uuid"
 	"io"
+	"net/http"
 )
 
 type CourseSearchRepo struct {
@@ -149,6 +150,10 @@ func (r *CourseSearchRepo) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("delete request: %w", err)
 	}
 	defer res.Body.Close()
+	// nothing to remove is the desired state
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
 	if res.IsError() {
 		return fmt.Errorf("delete error: %s", res.String())
 	}
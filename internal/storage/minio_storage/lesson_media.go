@@ -44,7 +44,7 @@ func (s *LessonStorage) UploadPhoto(
 		ext = ".bin"
 	}
 
-	objectKey = fmt.Sprintf("lessons/%s/photo%s", courseID.String(), ext)
+	objectKey = fmt.Sprintf("lessons/%s/photo_%s%s", courseID.String(), uuid.NewString(), ext)
 
 	if contentType == "" {
 		contentType = mime.TypeByExtension(ext)
@@ -99,7 +99,7 @@ func (s *LessonStorage) UploadVideo(
 		ext = ".bin"
 	}
 
-	objectKey = fmt.Sprintf("lessons/%s/video%s", courseID.String(), ext)
+	objectKey = fmt.Sprintf("lessons/%s/video_%s%s", courseID.String(), uuid.NewString(), ext)
 
 	if contentType == "" {
 		contentType = mime.TypeByExtension(ext)
@@ -140,3 +140,100 @@ func (s *LessonStorage) GetVideoURL(ctx context.Context, objectKey string) (stri
 func (s *LessonStorage) DeleteVideo(ctx context.Context, objectKey string) error {
 	return s.storage.client.RemoveObject(ctx, s.bucket, objectKey, minio.RemoveObjectOptions{})
 }
+
+// UploadSubmission stores a learner's assignment file under the assignment prefix, so that
+// deleting the assignment or the course can remove all of its submissions at once
+func (s *LessonStorage) UploadSubmission(
+	ctx context.Context,
+	courseID, contentID, userID uuid.UUID,
+	filename string,
+	reader io.Reader,
+	size int64,
+	contentType string,
+) (objectKey string, err error) {
+	ext := filepath.Ext(filename)
+	if ext == "" {
+		ext = ".bin"
+	}
+
+	objectKey = fmt.Sprintf("%s%s/%s%s", assignmentPrefix(courseID, contentID), userID.String(), uuid.NewString(), ext)
+
+	if contentType == "" {
+		contentType = mime.TypeByExtension(ext)
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+
+	_, err = s.storage.client.PutObject(
+		ctx,
+		s.bucket,
+		objectKey,
+		reader,
+		size,
+		minio.PutObjectOptions{ContentType: contentType},
+	)
+	if err != nil {
+		return "", err
+	}
+	return objectKey, nil
+}
+
+func (s *LessonStorage) GetSubmissionURL(ctx context.Context, objectKey string) (string, error) {
+	reqParams := make(url.Values)
+	url, err := s.storage.client.PresignedGetObject(
+		ctx,
+		s.bucket,
+		objectKey,
+		s.presignedTTL,
+		reqParams,
+	)
+	if err != nil {
+		return "", err
+	}
+	return url.String(), nil
+}
+
+func (s *LessonStorage) DeleteSubmission(ctx context.Context, objectKey string) error {
+	return s.storage.client.RemoveObject(ctx, s.bucket, objectKey, minio.RemoveObjectOptions{})
+}
+
+func (s *LessonStorage) DeleteAssignmentSubmissions(ctx context.Context, courseID, contentID uuid.UUID) error {
+	return s.removePrefix(ctx, assignmentPrefix(courseID, contentID))
+}
+
+func (s *LessonStorage) DeleteCourseSubmissions(ctx context.Context, courseID uuid.UUID) error {
+	return s.removePrefix(ctx, fmt.Sprintf("submissions/%s/", courseID.String()))
+}
+
+func assignmentPrefix(courseID, contentID uuid.UUID) string {
+	return fmt.Sprintf("submissions/%s/%s/", courseID.String(), contentID.String())
+}
+
+func (s *LessonStorage) removePrefix(ctx context.Context, prefix string) error {
+	var objects []minio.ObjectInfo
+	for object := range s.storage.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return object.Err
+		}
+		objects = append(objects, object)
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	objectsCh := make(chan minio.ObjectInfo, len(objects))
+	for _, object := range objects {
+		objectsCh <- object
+	}
+	close(objectsCh)
+
+	// the error channel has to be drained for RemoveObjects to finish
+	var err error
+	for removeErr := range s.storage.client.RemoveObjects(ctx, s.bucket, objectsCh, minio.RemoveObjectsOptions{}) {
+		if err == nil {
+			err = removeErr.Err
+		}
+	}
+	return err
+}
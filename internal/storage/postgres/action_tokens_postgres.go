@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ActionTokensPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewActionTokensPostgres(db *pgxpool.Pool) *ActionTokensPostgres {
+	return &ActionTokensPostgres{db: db}
+}
+
+func (r *ActionTokensPostgres) Create(ctx context.Context, token models.ActionToken) (*models.ActionToken, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// a new link invalidates the previous unused ones
+	deleteQuery := `DELETE FROM user_action_tokens WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`
+	if _, err = tx.Exec(ctx, deleteQuery, token.UserID, token.Purpose); err != nil {
+		return nil, err
+	}
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	insertQuery := `
+		INSERT INTO user_action_tokens (id, user_id, purpose, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	err = tx.QueryRow(ctx, insertQuery, token.ID, token.UserID, token.Purpose, token.ExpiresAt).Scan(&token.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *ActionTokensPostgres) Consume(ctx context.Context, id uuid.UUID, purpose string) (*models.ActionToken, error) {
+	query := `
+		UPDATE user_action_tokens
+		   SET used_at = NOW()
+		 WHERE id = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING id, user_id, purpose, created_at, expires_at, used_at
+	`
+	var token models.ActionToken
+	err := r.db.QueryRow(ctx, query, id, purpose).Scan(
+		&token.ID, &token.UserID, &token.Purpose, &token.CreatedAt, &token.ExpiresAt, &token.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app_errors.ErrInvalidActionToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AssignmentPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewAssignmentPostgres(db *pgxpool.Pool) *AssignmentPostgres {
+	return &AssignmentPostgres{db: db}
+}
+
+const submissionColumns = `
+	s.id, s.content_id, s.lesson_id, s.user_id, u.username, s.text, s.files, s.status, s.rubric_scores,
+	s.points, s.max_points, s.score, s.min_score, COALESCE(s.result, ''), s.feedback, s.graded_by, s.submitted_at, s.graded_at
+`
+
+func scanSubmission(row pgx.Row) (*models.AssignmentSubmission, error) {
+	var s models.AssignmentSubmission
+	var files, scores []byte
+	err := row.Scan(&s.ID, &s.ContentID, &s.LessonID, &s.UserID, &s.Username, &s.Text, &files, &s.Status, &scores,
+		&s.Points, &s.MaxPoints, &s.Score, &s.MinScore, &s.Result, &s.Feedback, &s.GradedBy, &s.SubmittedAt, &s.GradedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(files, &s.Files); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal submission files: %w", err)
+	}
+	if err := json.Unmarshal(scores, &s.Scores); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rubric scores: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *AssignmentPostgres) CreateSubmission(ctx context.Context, submission *models.AssignmentSubmission) error {
+	if submission.ID == uuid.Nil {
+		submission.ID = uuid.New()
+	}
+	files, err := json.Marshal(submission.Files)
+	if err != nil {
+		return fmt.Errorf("failed to marshal submission files: %w", err)
+	}
+	query := `
+		INSERT INTO assignment_submissions (id, content_id, lesson_id, user_id, text, files, status, max_points, min_score, submitted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err = r.db.Exec(ctx, query, submission.ID, submission.ContentID, submission.LessonID, submission.UserID,
+		submission.Text, files, submission.Status, submission.MaxPoints, submission.MinScore, submission.SubmittedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return app_errors.ErrSubmissionPending
+		}
+		return fmt.Errorf("failed to insert submission: %w", err)
+	}
+	return nil
+}
+
+func (r *AssignmentPostgres) SubmissionByID(ctx context.Context, id uuid.UUID) (*models.AssignmentSubmission, error) {
+	query := `SELECT ` + submissionColumns + `
+		  FROM assignment_submissions s
+		  JOIN users u ON u.id = s.user_id
+		 WHERE s.id = $1`
+	submission, err := scanSubmission(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app_errors.ErrSubmissionNotFound
+		}
+		return nil, err
+	}
+	return submission, nil
+}
+
+// GradeSubmission stores the author's grade; a submission is graded only once
+func (r *AssignmentPostgres) GradeSubmission(ctx context.Context, submission *models.AssignmentSubmission) error {
+	scores, err := json.Marshal(submission.Scores)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rubric scores: %w", err)
+	}
+	query := `
+		UPDATE assignment_submissions
+		   SET status        = $2,
+		       rubric_scores = $3,
+		       points        = $4,
+		       max_points    = $5,
+		       score         = $6,
+		       min_score     = $7,
+		       result        = $8,
+		       feedback      = $9,
+		       graded_by     = $10,
+		       graded_at     = $11
+		 WHERE id = $1 AND status = $12
+	`
+	cmd, err := r.db.Exec(ctx, query, submission.ID, submission.Status, scores, submission.Points, submission.MaxPoints,
+		submission.Score, submission.MinScore, submission.Result, submission.Feedback, submission.GradedBy, submission.GradedAt,
+		models.SubmissionStatusSubmitted)
+	if err != nil {
+		return fmt.Errorf("failed to grade submission: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrSubmissionGraded
+	}
+	return nil
+}
+
+// UserSubmissions returns the learner's submissions to an assignment, newest first
+func (r *AssignmentPostgres) UserSubmissions(ctx context.Context, contentID, userID uuid.UUID) ([]models.AssignmentSubmission, error) {
+	query := `SELECT ` + submissionColumns + `
+		  FROM assignment_submissions s
+		  JOIN users u ON u.id = s.user_id
+		 WHERE s.content_id = $1 AND s.user_id = $2
+		 ORDER BY s.submitted_at DESC`
+	return r.querySubmissions(ctx, query, contentID, userID)
+}
+
+// CourseSubmissions is the grading queue of a course, oldest first; an empty status lists all submissions
+func (r *AssignmentPostgres) CourseSubmissions(ctx context.Context, courseID uuid.UUID, status string, limit, offset int) ([]models.AssignmentSubmission, error) {
+	query := `SELECT ` + submissionColumns + `
+		  FROM assignment_submissions s
+		  JOIN users u ON u.id = s.user_id
+		  JOIN lessons l ON l.id = s.lesson_id
+		 WHERE l.course_id = $1 AND ($2 = '' OR s.status = $2)
+		 ORDER BY s.submitted_at
+		 LIMIT $3 OFFSET $4`
+	return r.querySubmissions(ctx, query, courseID, status, limit, offset)
+}
+
+func (r *AssignmentPostgres) querySubmissions(ctx context.Context, query string, args ...any) ([]models.AssignmentSubmission, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query submissions: %w", err)
+	}
+	defer rows.Close()
+
+	submissions := make([]models.AssignmentSubmission, 0)
+	for rows.Next() {
+		submission, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, *submission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
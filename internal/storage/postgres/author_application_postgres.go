@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuthorApplicationPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewAuthorApplicationPostgres(db *pgxpool.Pool) *AuthorApplicationPostgres {
+	return &AuthorApplicationPostgres{db: db}
+}
+
+const authorApplicationColumns = `
+	a.id, a.user_id, u.username, a.motivation, a.status, COALESCE(a.rejection_reason, ''),
+	a.reviewed_by, a.created_at, a.reviewed_at
+`
+
+func scanAuthorApplication(row pgx.Row) (*models.AuthorApplication, error) {
+	var a models.AuthorApplication
+	err := row.Scan(&a.ID, &a.UserID, &a.Username, &a.Motivation, &a.Status, &a.RejectionReason,
+		&a.ReviewedBy, &a.CreatedAt, &a.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *AuthorApplicationPostgres) Create(ctx context.Context, app models.AuthorApplication) (*models.AuthorApplication, error) {
+	if app.ID == uuid.Nil {
+		app.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO author_applications (id, user_id, motivation)
+		VALUES ($1, $2, $3)
+		RETURNING status, created_at
+	`
+	err := r.db.QueryRow(ctx, query, app.ID, app.UserID, app.Motivation).Scan(&app.Status, &app.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, app_errors.ErrApplicationPending
+		}
+		return nil, fmt.Errorf("failed to insert author application: %w", err)
+	}
+	return &app, nil
+}
+
+func (r *AuthorApplicationPostgres) ApplicationByID(ctx context.Context, id uuid.UUID) (*models.AuthorApplication, error) {
+	query := `SELECT ` + authorApplicationColumns + `
+		  FROM author_applications a
+		  JOIN users u ON u.id = a.user_id
+		 WHERE a.id = $1`
+	app, err := scanAuthorApplication(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app_errors.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+	return app, nil
+}
+
+func (r *AuthorApplicationPostgres) UserApplications(ctx context.Context, userID uuid.UUID) ([]models.AuthorApplication, error) {
+	query := `SELECT ` + authorApplicationColumns + `
+		  FROM author_applications a
+		  JOIN users u ON u.id = a.user_id
+		 WHERE a.user_id = $1
+		 ORDER BY a.created_at DESC`
+	return r.queryApplications(ctx, query, userID)
+}
+
+func (r *AuthorApplicationPostgres) ListApplications(ctx context.Context, status string, limit, offset int) ([]models.AuthorApplication, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM author_applications WHERE ($1 = '' OR status = $1)`
+	if err := r.db.QueryRow(ctx, countQuery, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + authorApplicationColumns + `
+		  FROM author_applications a
+		  JOIN users u ON u.id = a.user_id
+		 WHERE ($1 = '' OR a.status = $1)
+		 ORDER BY a.created_at
+		 LIMIT $2 OFFSET $3`
+	apps, err := r.queryApplications(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return apps, total, nil
+}
+
+// Review moves a pending application to its final status; reviewed applications are never changed again
+func (r *AuthorApplicationPostgres) Review(ctx context.Context, id, reviewerID uuid.UUID, status, reason string) error {
+	query := `
+		UPDATE author_applications
+		   SET status           = $2,
+		       rejection_reason = NULLIF($3, ''),
+		       reviewed_by      = $4,
+		       reviewed_at      = NOW()
+		 WHERE id = $1 AND status = 'pending'
+	`
+	cmd, err := r.db.Exec(ctx, query, id, status, reason, reviewerID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrApplicationReviewed
+	}
+	return nil
+}
+
+func (r *AuthorApplicationPostgres) queryApplications(ctx context.Context, query string, args ...any) ([]models.AuthorApplication, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apps := make([]models.AuthorApplication, 0)
+	for rows.Next() {
+		app, err := scanAuthorApplication(rows)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, *app)
+	}
+	return apps, rows.Err()
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"SkillForge/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CleanupPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewCleanupPostgres(db *pgxpool.Pool) *CleanupPostgres {
+	return &CleanupPostgres{db: db}
+}
+
+func (r *CleanupPostgres) SaveCleanupFailures(ctx context.Context, failures []models.CleanupFailure) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO cleanup_failures (id, course_id, target, object_key, content_type, error)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING attempts, created_at, last_tried_at
+	`
+	for i := range failures {
+		f := &failures[i]
+		if f.ID == uuid.Nil {
+			f.ID = uuid.New()
+		}
+		err := tx.QueryRow(ctx, query, f.ID, f.CourseID, f.Target, f.ObjectKey, f.ContentType, f.Error).
+			Scan(&f.Attempts, &f.CreatedAt, &f.LastTriedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save cleanup failure: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// CleanupFailures returns the pending failures, oldest first
+func (r *CleanupPostgres) CleanupFailures(ctx context.Context) ([]models.CleanupFailure, error) {
+	query := `
+		SELECT id, course_id, target, object_key, content_type, error, attempts, created_at, last_tried_at
+		  FROM cleanup_failures
+		 ORDER BY created_at
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cleanup failures: %w", err)
+	}
+	defer rows.Close()
+
+	failures := make([]models.CleanupFailure, 0)
+	for rows.Next() {
+		var f models.CleanupFailure
+		err := rows.Scan(&f.ID, &f.CourseID, &f.Target, &f.ObjectKey, &f.ContentType, &f.Error, &f.Attempts,
+			&f.CreatedAt, &f.LastTriedAt)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return failures, nil
+}
+
+func (r *CleanupPostgres) DeleteCleanupFailure(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM cleanup_failures WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete cleanup failure: %w", err)
+	}
+	return nil
+}
+
+// RecordCleanupRetry keeps a failure that failed again with the latest error
+func (r *CleanupPostgres) RecordCleanupRetry(ctx context.Context, failure *models.CleanupFailure) error {
+	query := `
+		UPDATE cleanup_failures
+		   SET error         = $2,
+		       attempts      = attempts + 1,
+		       last_tried_at = NOW()
+		 WHERE id = $1
+		RETURNING attempts, last_tried_at
+	`
+	if err := r.db.QueryRow(ctx, query, failure.ID, failure.Error).Scan(&failure.Attempts, &failure.LastTriedAt); err != nil {
+		return fmt.Errorf("failed to update cleanup failure: %w", err)
+	}
+	return nil
+}
@@ -21,8 +21,8 @@ func (r *ContentPostgres) CreateContent(ctx context.Context, c models.CourseCont
 	query := `
     INSERT INTO contents (
         id, lesson_id, type, order_num,
-        text, object_key, quiz_json, created_at, updated_at
-    ) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+        text, object_key, quiz_json, assignment_json, created_at, updated_at
+    ) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
     `
 	now := time.Now().UTC()
 	if c.ID == uuid.Nil {
@@ -33,7 +33,7 @@ func (r *ContentPostgres) CreateContent(ctx context.Context, c models.CourseCont
 
 	_, err := r.db.Exec(ctx, query,
 		c.ID, c.LessonID, c.Type, c.Order,
-		c.Text, c.ObjectKey, c.QuizJSON,
+		c.Text, c.ObjectKey, c.QuizJSON, c.AssignmentJSON,
 		c.CreatedAt, c.UpdatedAt,
 	)
 	if err != nil {
@@ -44,7 +44,7 @@ func (r *ContentPostgres) CreateContent(ctx context.Context, c models.CourseCont
 
 func (r *ContentPostgres) GetContentsByLesson(ctx context.Context, lessonID uuid.UUID) ([]models.CourseContent, error) {
 	query := `
-    SELECT id, lesson_id, type, order_num, text, object_key, quiz_json, created_at, updated_at
+    SELECT id, lesson_id, type, order_num, text, object_key, quiz_json, assignment_json, created_at, updated_at
       FROM contents
      WHERE lesson_id = $1
   ORDER BY order_num
@@ -60,7 +60,7 @@ func (r *ContentPostgres) GetContentsByLesson(ctx context.Context, lessonID uuid
 		var c models.CourseContent
 		if err := rows.Scan(
 			&c.ID, &c.LessonID, &c.Type, &c.Order,
-			&c.Text, &c.ObjectKey, &c.QuizJSON,
+			&c.Text, &c.ObjectKey, &c.QuizJSON, &c.AssignmentJSON,
 			&c.CreatedAt, &c.UpdatedAt,
 		); err != nil {
 			return nil, err
@@ -78,12 +78,13 @@ func (r *ContentPostgres) UpdateContent(ctx context.Context, c models.CourseCont
         text = $3,
         object_key = $4,
         quiz_json = $5,
-        updated_at = $6
-     WHERE id = $7
+        assignment_json = $6,
+        updated_at = $7
+     WHERE id = $8
     `
 	c.UpdatedAt = time.Now().UTC()
 	_, err := r.db.Exec(ctx, query,
-		c.Type, c.Order, c.Text, c.ObjectKey, c.QuizJSON, c.UpdatedAt, c.ID,
+		c.Type, c.Order, c.Text, c.ObjectKey, c.QuizJSON, c.AssignmentJSON, c.UpdatedAt, c.ID,
 	)
 	if err != nil {
 		return models.CourseContent{}, fmt.Errorf("failed to update content: %w", err)
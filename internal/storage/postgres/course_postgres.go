@@ -36,7 +36,7 @@ func (r *CoursePostgres) NewCourse(ctx context.Context, course *models.Course) (
 			$1, $2, $3, $4, $5, $6,
 			$7, $8, $9
 		)
-		RETURNING id, created_at, updated_at
+		RETURNING id, created_at, updated_at, default_min_score, score_policy
 	`
 	var returnedID uuid.UUID
 	var returnedCreated, returnedUpdated time.Time
@@ -52,7 +52,7 @@ func (r *CoursePostgres) NewCourse(ctx context.Context, course *models.Course) (
 		course.AuthorID,
 		course.Status,
 		course.StarsCount,
-	).Scan(&returnedID, &returnedCreated, &returnedUpdated)
+	).Scan(&returnedID, &returnedCreated, &returnedUpdated, &course.DefaultMinScore, &course.ScorePolicy)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -73,7 +73,9 @@ func (r *CoursePostgres) CourseByID(ctx context.Context, id uuid.UUID) (*models.
             updated_at,
             author_id,
             status,
-            stars_count
+            stars_count,
+            default_min_score,
+            score_policy
         FROM courses
         WHERE id = $1
     `
@@ -89,6 +91,8 @@ func (r *CoursePostgres) CourseByID(ctx context.Context, id uuid.UUID) (*models.
 		&course.AuthorID,
 		&course.Status,
 		&course.StarsCount,
+		&course.DefaultMinScore,
+		&course.ScorePolicy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -191,6 +195,27 @@ func (r *CoursePostgres) UpdateCourseLogo(ctx context.Context, courseID uuid.UUI
 	return nil
 }
 
+func (r *CoursePostgres) UpdateCourseInfo(ctx context.Context, course *models.Course) error {
+	const query = `
+		UPDATE courses
+		   SET title             = $2,
+		       description       = $3,
+		       default_min_score = $4,
+		       score_policy      = $5,
+		       updated_at        = NOW()
+		 WHERE id = $1
+		RETURNING updated_at
+	`
+	err := r.db.QueryRow(ctx, query, course.ID, course.Title, course.Description, course.DefaultMinScore, course.ScorePolicy).Scan(&course.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return app_errors.ErrCourseNotFound
+		}
+		return err
+	}
+	return nil
+}
+
 func (r *CoursePostgres) ListCoursesByAuthor(ctx context.Context, authorID uuid.UUID) ([]models.Course, error) {
 	query := `
         SELECT id, title, description, logo_object_key, created_at, updated_at, author_id, status, stars_count
@@ -233,3 +258,14 @@ func (r *CoursePostgres) DecrementStars(ctx context.Context, courseID uuid.UUID)
     `, courseID)
 	return err
 }
+
+func (r *CoursePostgres) DeleteCourse(ctx context.Context, id uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM courses WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrCourseNotFound
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"SkillForge/internal/app_errors"
 	"SkillForge/internal/models"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -46,12 +48,12 @@ func (r *LessonPostgres) CreateLesson(ctx context.Context, lesson models.Lesson)
 	insertQuery := `
     INSERT INTO lessons (
         id, course_id, module_id,
-        lesson_title, lesson_order, created_at, updated_at
-    ) VALUES ($1, $2, $3, $4, $5, $6, $7)
+        lesson_title, lesson_order, free_preview, created_at, updated_at
+    ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     `
 	_, err = tx.Exec(ctx, insertQuery,
 		lesson.ID, lesson.CourseID, lesson.ModuleID,
-		lesson.LessonTitle, lesson.LessonOrder, lesson.CreatedAt, lesson.UpdatedAt,
+		lesson.LessonTitle, lesson.LessonOrder, lesson.FreePreview, lesson.CreatedAt, lesson.UpdatedAt,
 	)
 	if err != nil {
 		if pgErr := UnwrapPgError(err); pgErr != nil && pgErr.Code == "23505" {
@@ -70,21 +72,36 @@ func (r *LessonPostgres) GetLessonByID(ctx context.Context, id uuid.UUID) (model
 	var lesson models.Lesson
 	query := `
     SELECT id, course_id, module_id,
-           lesson_title, lesson_order, created_at, updated_at
+           lesson_title, lesson_order, free_preview, created_at, updated_at
       FROM lessons
      WHERE id = $1
     `
 	row := r.db.QueryRow(ctx, query, id)
 	err := row.Scan(
 		&lesson.ID, &lesson.CourseID, &lesson.ModuleID,
-		&lesson.LessonTitle, &lesson.LessonOrder, &lesson.CreatedAt, &lesson.UpdatedAt,
+		&lesson.LessonTitle, &lesson.LessonOrder, &lesson.FreePreview, &lesson.CreatedAt, &lesson.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Lesson{}, app_errors.ErrLessonNotFound
+		}
 		return models.Lesson{}, fmt.Errorf("lesson not found: %w", err)
 	}
 	return lesson, nil
 }
 
+func (r *LessonPostgres) SetLessonFreePreview(ctx context.Context, lessonID uuid.UUID, freePreview bool) error {
+	query := `UPDATE lessons SET free_preview = $2, updated_at = NOW() WHERE id = $1`
+	cmd, err := r.db.Exec(ctx, query, lessonID, freePreview)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrLessonNotFound
+	}
+	return nil
+}
+
 func (r *LessonPostgres) GetMaxLessonOrder(ctx context.Context, moduleID uuid.UUID) (int, error) {
 	var max int
 	query := `SELECT COALESCE(MAX(lesson_order), 0) FROM lessons WHERE module_id = $1`
@@ -230,7 +247,7 @@ func (r *LessonPostgres) CourseContent(ctx context.Context, courseID uuid.UUID)
 	}
 
 	lessonsQuery := `
-        SELECT id, course_id, module_id, lesson_title, lesson_order, created_at, updated_at
+        SELECT id, course_id, module_id, lesson_title, lesson_order, free_preview, created_at, updated_at
         FROM lessons
         WHERE course_id = $1
         ORDER BY module_id, lesson_order
@@ -244,7 +261,7 @@ func (r *LessonPostgres) CourseContent(ctx context.Context, courseID uuid.UUID)
 	lessonsByModule := make(map[uuid.UUID][]models.Lesson)
 	for lessonRows.Next() {
 		var l models.Lesson
-		if err := lessonRows.Scan(&l.ID, &l.CourseID, &l.ModuleID, &l.LessonTitle, &l.LessonOrder, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		if err := lessonRows.Scan(&l.ID, &l.CourseID, &l.ModuleID, &l.LessonTitle, &l.LessonOrder, &l.FreePreview, &l.CreatedAt, &l.UpdatedAt); err != nil {
 			return nil, err
 		}
 		lessonsByModule[l.ModuleID] = append(lessonsByModule[l.ModuleID], l)
@@ -278,7 +295,7 @@ func (r *LessonPostgres) GetModuleByID(ctx context.Context, moduleID uuid.UUID)
 
 func (r *LessonPostgres) LessonsByCourse(ctx context.Context, courseID uuid.UUID) ([]models.Lesson, error) {
 	query := `
-        SELECT id, course_id, module_id, lesson_title, lesson_order, created_at, updated_at
+        SELECT id, course_id, module_id, lesson_title, lesson_order, free_preview, created_at, updated_at
           FROM lessons
          WHERE course_id = $1
          ORDER BY lesson_order
@@ -293,7 +310,7 @@ func (r *LessonPostgres) LessonsByCourse(ctx context.Context, courseID uuid.UUID
 	for rows.Next() {
 		var l models.Lesson
 		if err := rows.Scan(
-			&l.ID, &l.CourseID, &l.ModuleID, &l.LessonTitle, &l.LessonOrder, &l.CreatedAt, &l.UpdatedAt,
+			&l.ID, &l.CourseID, &l.ModuleID, &l.LessonTitle, &l.LessonOrder, &l.FreePreview, &l.CreatedAt, &l.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -389,12 +406,12 @@ func (r *LessonPostgres) CreateContent(ctx context.Context, content models.Cours
 
 	insertQuery := `
     INSERT INTO contents (
-        id, lesson_id, type, order_num, text, object_key, quiz_json, created_at, updated_at
-    ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        id, lesson_id, type, order_num, text, object_key, quiz_json, assignment_json, created_at, updated_at
+    ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
     `
 	_, err = tx.Exec(ctx, insertQuery,
 		content.ID, content.LessonID, content.Type, content.Order,
-		content.Text, content.ObjectKey, content.QuizJSON,
+		content.Text, content.ObjectKey, content.QuizJSON, content.AssignmentJSON,
 		content.CreatedAt, content.UpdatedAt,
 	)
 	if err != nil {
@@ -410,16 +427,16 @@ func (r *LessonPostgres) CreateContent(ctx context.Context, content models.Cours
 func (r *LessonPostgres) GetLessonDetail(ctx context.Context, lessonID uuid.UUID) (models.LessonDetail, error) {
 	var detail models.LessonDetail
 	query := `
-        SELECT id, course_id, module_id, lesson_title, lesson_order, created_at, updated_at 
+        SELECT id, course_id, module_id, lesson_title, lesson_order, free_preview, created_at, updated_at 
           FROM lessons 
          WHERE id = $1
     `
 	row := r.db.QueryRow(ctx, query, lessonID)
-	if err := row.Scan(&detail.Lesson.ID, &detail.Lesson.CourseID, &detail.Lesson.ModuleID, &detail.Lesson.LessonTitle, &detail.Lesson.LessonOrder, &detail.Lesson.CreatedAt, &detail.Lesson.UpdatedAt); err != nil {
+	if err := row.Scan(&detail.Lesson.ID, &detail.Lesson.CourseID, &detail.Lesson.ModuleID, &detail.Lesson.LessonTitle, &detail.Lesson.LessonOrder, &detail.Lesson.FreePreview, &detail.Lesson.CreatedAt, &detail.Lesson.UpdatedAt); err != nil {
 		return detail, fmt.Errorf("lesson not found: %w", err)
 	}
 	contentsQuery := `
-        SELECT id, lesson_id, type, order_num, text, object_key, quiz_json, created_at, updated_at 
+        SELECT id, lesson_id, type, order_num, text, object_key, quiz_json, assignment_json, created_at, updated_at 
           FROM contents 
          WHERE lesson_id = $1 
          ORDER BY order_num
@@ -433,7 +450,7 @@ func (r *LessonPostgres) GetLessonDetail(ctx context.Context, lessonID uuid.UUID
 	var contents []models.CourseContent
 	for rows.Next() {
 		var c models.CourseContent
-		if err := rows.Scan(&c.ID, &c.LessonID, &c.Type, &c.Order, &c.Text, &c.ObjectKey, &c.QuizJSON, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.LessonID, &c.Type, &c.Order, &c.Text, &c.ObjectKey, &c.QuizJSON, &c.AssignmentJSON, &c.CreatedAt, &c.UpdatedAt); err != nil {
 			return detail, err
 		}
 		contents = append(contents, c)
@@ -444,7 +461,7 @@ func (r *LessonPostgres) GetLessonDetail(ctx context.Context, lessonID uuid.UUID
 
 func (r *LessonPostgres) LessonsByModule(ctx context.Context, moduleID uuid.UUID) ([]models.Lesson, error) {
 	query := `
-        SELECT id, course_id, module_id, lesson_title, lesson_order, created_at, updated_at
+        SELECT id, course_id, module_id, lesson_title, lesson_order, free_preview, created_at, updated_at
           FROM lessons
          WHERE module_id = $1
          ORDER BY lesson_order
@@ -458,7 +475,7 @@ func (r *LessonPostgres) LessonsByModule(ctx context.Context, moduleID uuid.UUID
 	var lessons []models.Lesson
 	for rows.Next() {
 		var lesson models.Lesson
-		if err := rows.Scan(&lesson.ID, &lesson.CourseID, &lesson.ModuleID, &lesson.LessonTitle, &lesson.LessonOrder, &lesson.CreatedAt, &lesson.UpdatedAt); err != nil {
+		if err := rows.Scan(&lesson.ID, &lesson.CourseID, &lesson.ModuleID, &lesson.LessonTitle, &lesson.LessonOrder, &lesson.FreePreview, &lesson.CreatedAt, &lesson.UpdatedAt); err != nil {
 			return nil, err
 		}
 		lessons = append(lessons, lesson)
@@ -469,61 +486,195 @@ func (r *LessonPostgres) LessonsByModule(ctx context.Context, moduleID uuid.UUID
 	return lessons, nil
 }
 
-func (r *LessonPostgres) UpsertContent(ctx context.Context, content models.CourseContent) (*models.CourseContent, error) {
-	var existingID uuid.UUID
-	err := r.db.QueryRow(ctx, `SELECT id FROM contents WHERE lesson_id = $1 LIMIT 1`, content.LessonID).Scan(&existingID)
-	now := time.Now().UTC()
+func (r *LessonPostgres) InsertContent(ctx context.Context, content models.CourseContent) (*models.CourseContent, error) {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		if err.Error() == "no rows in result set" || err.Error() == "pg: no rows in result set" {
-			content.Order = 1
-			content.CreatedAt = now
-			content.UpdatedAt = now
-			if content.ID == uuid.Nil {
-				content.ID = uuid.New()
-			}
-			insertQuery := `
-                INSERT INTO contents (
-                    id, lesson_id, type, order_num, text, object_key, quiz_json, created_at, updated_at
-                ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-            `
-			_, err = r.db.Exec(ctx, insertQuery,
-				content.ID, content.LessonID, content.Type, content.Order,
-				content.Text, content.ObjectKey, content.QuizJSON,
-				content.CreatedAt, content.UpdatedAt,
-			)
-			if err != nil {
-				return nil, err
-			}
-			return &content, nil
-		} else {
-			return nil, err
-		}
+		return nil, err
 	}
-	updateQuery := `
-        UPDATE contents SET type = $1, text = $2, object_key = $3, quiz_json = $4, updated_at = $5
-        WHERE lesson_id = $6
+	defer tx.Rollback(ctx)
+
+	var max int
+	err = tx.QueryRow(ctx, `SELECT COALESCE(MAX(order_num), 0) FROM contents WHERE lesson_id = $1`, content.LessonID).Scan(&max)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get max order_num: %w", err)
+	}
+	if content.Order < 1 || content.Order > max+1 {
+		return nil, app_errors.ErrInvalidContentPosition
+	}
+
+	// order_num is unique per lesson, so blocks are moved through negative values
+	// to avoid collisions while shifting
+	shiftQuery := `
+        UPDATE contents SET order_num = -(order_num + 1)
+         WHERE lesson_id = $1 AND order_num >= $2
     `
-	_, err = r.db.Exec(ctx, updateQuery,
-		content.Type, content.Text, content.ObjectKey, content.QuizJSON,
-		now, content.LessonID,
+	if _, err = tx.Exec(ctx, shiftQuery, content.LessonID, content.Order); err != nil {
+		return nil, fmt.Errorf("failed to shift contents: %w", err)
+	}
+	restoreQuery := `UPDATE contents SET order_num = -order_num WHERE lesson_id = $1 AND order_num < 0`
+	if _, err = tx.Exec(ctx, restoreQuery, content.LessonID); err != nil {
+		return nil, fmt.Errorf("failed to shift contents: %w", err)
+	}
+
+	now := time.Now().UTC()
+	content.CreatedAt = now
+	content.UpdatedAt = now
+	if content.ID == uuid.Nil {
+		content.ID = uuid.New()
+	}
+
+	insertQuery := `
+    INSERT INTO contents (
+        id, lesson_id, type, order_num, text, object_key, quiz_json, assignment_json, created_at, updated_at
+    ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    `
+	_, err = tx.Exec(ctx, insertQuery,
+		content.ID, content.LessonID, content.Type, content.Order,
+		content.Text, content.ObjectKey, content.QuizJSON, content.AssignmentJSON,
+		content.CreatedAt, content.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
-	content.ID = existingID
-	content.UpdatedAt = now
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 	return &content, nil
 }
 
-func (r *LessonPostgres) UpdateLessonProgress(ctx context.Context, lessonID, userID uuid.UUID, status string, score float64) error {
+func (r *LessonPostgres) GetContentByID(ctx context.Context, contentID uuid.UUID) (models.CourseContent, error) {
+	query := `
+        SELECT id, lesson_id, type, order_num, text, object_key, quiz_json, assignment_json, created_at, updated_at
+          FROM contents
+         WHERE id = $1
+    `
+	var c models.CourseContent
+	err := r.db.QueryRow(ctx, query, contentID).Scan(&c.ID, &c.LessonID, &c.Type, &c.Order, &c.Text, &c.ObjectKey, &c.QuizJSON, &c.AssignmentJSON, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.CourseContent{}, app_errors.ErrContentNotFound
+		}
+		return models.CourseContent{}, err
+	}
+	return c, nil
+}
+
+func (r *LessonPostgres) UpdateContent(ctx context.Context, content models.CourseContent) (*models.CourseContent, error) {
+	query := `
+        UPDATE contents SET text = $2, object_key = $3, quiz_json = $4, assignment_json = $5, updated_at = $6
+         WHERE id = $1
+     RETURNING lesson_id, type, order_num, created_at
+    `
+	content.UpdatedAt = time.Now().UTC()
+	err := r.db.QueryRow(ctx, query,
+		content.ID, content.Text, content.ObjectKey, content.QuizJSON, content.AssignmentJSON, content.UpdatedAt,
+	).Scan(&content.LessonID, &content.Type, &content.Order, &content.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app_errors.ErrContentNotFound
+		}
+		return nil, fmt.Errorf("failed to update content: %w", err)
+	}
+	return &content, nil
+}
+
+func (r *LessonPostgres) DeleteContentAndUpdateOrder(ctx context.Context, contentID, lessonID uuid.UUID, order int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	cmd, err := tx.Exec(ctx, `DELETE FROM contents WHERE id = $1`, contentID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrContentNotFound
+	}
+
+	shiftQuery := `
+        UPDATE contents SET order_num = -(order_num - 1)
+         WHERE lesson_id = $1 AND order_num > $2
+    `
+	if _, err = tx.Exec(ctx, shiftQuery, lessonID, order); err != nil {
+		return err
+	}
+	restoreQuery := `UPDATE contents SET order_num = -order_num WHERE lesson_id = $1 AND order_num < 0`
+	if _, err = tx.Exec(ctx, restoreQuery, lessonID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *LessonPostgres) ReorderContents(ctx context.Context, lessonID uuid.UUID, contentIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT id FROM contents WHERE lesson_id = $1 FOR UPDATE`, lessonID)
+	if err != nil {
+		return fmt.Errorf("failed to query contents: %w", err)
+	}
+	existing := make(map[uuid.UUID]struct{})
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[id] = struct{}{}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	if len(existing) != len(contentIDs) {
+		return app_errors.ErrInvalidContentOrder
+	}
+	seen := make(map[uuid.UUID]struct{}, len(contentIDs))
+	for _, id := range contentIDs {
+		if _, ok := existing[id]; !ok {
+			return app_errors.ErrInvalidContentOrder
+		}
+		if _, dup := seen[id]; dup {
+			return app_errors.ErrInvalidContentOrder
+		}
+		seen[id] = struct{}{}
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE contents SET order_num = -order_num WHERE lesson_id = $1`, lessonID); err != nil {
+		return fmt.Errorf("failed to reset content order: %w", err)
+	}
+	now := time.Now().UTC()
+	updateQuery := `UPDATE contents SET order_num = $1, updated_at = $2 WHERE id = $3`
+	for i, id := range contentIDs {
+		if _, err = tx.Exec(ctx, updateQuery, i+1, now, id); err != nil {
+			return fmt.Errorf("failed to update content order: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *LessonPostgres) UpdateLessonProgress(ctx context.Context, progress models.LessonProgress) error {
+	blocks, err := json.Marshal(progress.Blocks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal block results: %w", err)
+	}
 	query := `
-		INSERT INTO lesson_progress (user_id, lesson_id, status, score, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO lesson_progress (user_id, lesson_id, status, score, min_score, blocks, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT (user_id, lesson_id) 
-		DO UPDATE SET status = $3, score = $4, updated_at = $5
+		DO UPDATE SET status = $3, score = $4, min_score = $5, blocks = $6, updated_at = $7
 	`
 	now := time.Now().UTC()
-	_, err := r.db.Exec(ctx, query, userID, lessonID, status, score, now)
+	_, err = r.db.Exec(ctx, query, progress.UserID, progress.LessonID, progress.Status, progress.Score, progress.MinScore, blocks, now)
 	if err != nil {
 		return fmt.Errorf("failed to update lesson progress: %w", err)
 	}
@@ -532,29 +683,153 @@ func (r *LessonPostgres) UpdateLessonProgress(ctx context.Context, lessonID, use
 
 func (r *LessonPostgres) GetLessonProgress(ctx context.Context, lessonID, userID uuid.UUID) (models.LessonProgress, error) {
 	query := `
-		SELECT user_id, lesson_id, status, score, updated_at
+		SELECT user_id, lesson_id, status, score, min_score, blocks, updated_at
 		FROM lesson_progress
 		WHERE user_id = $1 AND lesson_id = $2
 	`
 	var progress models.LessonProgress
+	var blocks []byte
 	err := r.db.QueryRow(ctx, query, userID, lessonID).Scan(
 		&progress.UserID,
 		&progress.LessonID,
 		&progress.Status,
 		&progress.Score,
+		&progress.MinScore,
+		&blocks,
 		&progress.UpdatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return models.LessonProgress{
-				UserID:    userID,
-				LessonID:  lessonID,
-				Status:    models.LessonStatusFailed,
-				Score:     0,
-				UpdatedAt: time.Now().UTC(),
-			}, nil
+			return models.LessonProgress{}, app_errors.ErrLessonNotStarted
 		}
 		return models.LessonProgress{}, fmt.Errorf("failed to get lesson progress: %w", err)
 	}
+	if err := json.Unmarshal(blocks, &progress.Blocks); err != nil {
+		return models.LessonProgress{}, fmt.Errorf("failed to unmarshal block results: %w", err)
+	}
 	return progress, nil
 }
+
+// MarkLessonCompleted records a lesson without graded blocks as completed; an existing result is kept
+func (r *LessonPostgres) MarkLessonCompleted(ctx context.Context, lessonID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO lesson_progress (user_id, lesson_id, status, score, min_score, blocks, updated_at)
+		VALUES ($1, $2, $3, 0, 0, '[]'::jsonb, $4)
+		ON CONFLICT (user_id, lesson_id) DO NOTHING
+	`
+	_, err := r.db.Exec(ctx, query, userID, lessonID, models.LessonStatusCompleted, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to mark lesson completed: %w", err)
+	}
+	return nil
+}
+
+// lessonGradedSQL tells whether lesson l has quiz or assignment blocks. Such a lesson is completed only
+// when passed; a lesson marked completed before its first graded block was added no longer counts.
+const (
+	lessonGradedSQL    = `EXISTS (SELECT 1 FROM contents gc WHERE gc.lesson_id = l.id AND gc.type IN ('quiz', 'assignment'))`
+	lessonCompletedSQL = `COALESCE(p.status = 'passed' OR (p.status = 'completed' AND NOT ` + lessonGradedSQL + `), false)`
+)
+
+// CourseLessonProgress lists the course lessons in outline order with the learner's status;
+// modules without lessons come with a nil lesson id
+func (r *LessonPostgres) CourseLessonProgress(ctx context.Context, courseID, userID uuid.UUID) ([]models.Module, []models.LessonCompletion, error) {
+	query := `
+		SELECT m.id, m.title, m.module_order, l.id, COALESCE(l.lesson_title, ''),
+		       ` + lessonGradedSQL + `, COALESCE(p.status, ''), ` + lessonCompletedSQL + `
+		  FROM modules m
+		  LEFT JOIN lessons l ON l.module_id = m.id
+		  LEFT JOIN lesson_progress p ON p.lesson_id = l.id AND p.user_id = $2
+		 WHERE m.course_id = $1
+		 ORDER BY m.module_order, l.lesson_order
+	`
+	rows, err := r.db.Query(ctx, query, courseID, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query course progress: %w", err)
+	}
+	defer rows.Close()
+
+	var modules []models.Module
+	var lessons []models.LessonCompletion
+	for rows.Next() {
+		var module models.Module
+		var lessonID *uuid.UUID
+		var lesson models.LessonCompletion
+		if err := rows.Scan(&module.ID, &module.Title, &module.Order, &lessonID, &lesson.Title, &lesson.Graded, &lesson.Status, &lesson.Completed); err != nil {
+			return nil, nil, err
+		}
+		if len(modules) == 0 || modules[len(modules)-1].ID != module.ID {
+			module.CourseID = courseID
+			modules = append(modules, module)
+		}
+		if lessonID == nil {
+			continue
+		}
+		lesson.LessonID = *lessonID
+		lesson.ModuleID = module.ID
+		lessons = append(lessons, lesson)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return modules, lessons, nil
+}
+
+// SubscribedCourseCompletion returns the share of completed lessons in percent for each course the user
+// is subscribed to; courses without lessons are left out
+func (r *LessonPostgres) SubscribedCourseCompletion(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]float64, error) {
+	query := `
+		SELECT l.course_id, COUNT(*), COUNT(*) FILTER (WHERE ` + lessonCompletedSQL + `)
+		  FROM course_subscriptions cs
+		  JOIN lessons l ON l.course_id = cs.course_id
+		  LEFT JOIN lesson_progress p ON p.lesson_id = l.id AND p.user_id = cs.user_id
+		 WHERE cs.user_id = $1
+		 GROUP BY l.course_id
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query course completion: %w", err)
+	}
+	defer rows.Close()
+
+	completion := make(map[uuid.UUID]float64)
+	for rows.Next() {
+		var courseID uuid.UUID
+		var total, completed int
+		if err := rows.Scan(&courseID, &total, &completed); err != nil {
+			return nil, err
+		}
+		completion[courseID] = float64(completed) / float64(total) * 100
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
+
+func (r *LessonPostgres) CourseMediaContents(ctx context.Context, courseID uuid.UUID) ([]models.CourseContent, error) {
+	query := `
+        SELECT c.id, c.lesson_id, c.type, c.order_num, c.text, c.object_key, c.quiz_json, c.assignment_json, c.created_at, c.updated_at
+          FROM contents c
+          JOIN lessons l ON l.id = c.lesson_id
+         WHERE l.course_id = $1 AND c.object_key IS NOT NULL
+    `
+	rows, err := r.db.Query(ctx, query, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query course media: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []models.CourseContent
+	for rows.Next() {
+		var c models.CourseContent
+		if err := rows.Scan(&c.ID, &c.LessonID, &c.Type, &c.Order, &c.Text, &c.ObjectKey, &c.QuizJSON, &c.AssignmentJSON, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		contents = append(contents, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"SkillForge/internal/app_errors"
+	"SkillForge/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type QuizAttemptPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewQuizAttemptPostgres(db *pgxpool.Pool) *QuizAttemptPostgres {
+	return &QuizAttemptPostgres{db: db}
+}
+
+const quizAttemptColumns = `
+	a.id, a.user_id, u.username, a.lesson_id, a.content_id, a.answers, a.question_results,
+	a.score, a.min_score, a.status, a.late, a.served_questions, a.started_at, a.deadline, a.submitted_at
+`
+
+func scanQuizAttempt(row pgx.Row) (*models.QuizAttempt, error) {
+	var a models.QuizAttempt
+	var answers, questions, served []byte
+	err := row.Scan(&a.ID, &a.UserID, &a.Username, &a.LessonID, &a.ContentID, &answers, &questions,
+		&a.Score, &a.MinScore, &a.Status, &a.Late, &served, &a.StartedAt, &a.Deadline, &a.SubmittedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(answers, &a.Answers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attempt answers: %w", err)
+	}
+	if err := json.Unmarshal(questions, &a.Questions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attempt question results: %w", err)
+	}
+	if served != nil {
+		if err := json.Unmarshal(served, &a.Served); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal served questions: %w", err)
+		}
+	}
+	return &a, nil
+}
+
+// CreateAttempt stores a new attempt once allow accepts the learner's earlier attempts at the quiz block.
+// The check and the insert run under a lock on the learner and the block, so parallel requests cannot all
+// pass the attempt limit.
+func (r *QuizAttemptPostgres) CreateAttempt(ctx context.Context, attempt *models.QuizAttempt, allow func([]models.QuizAttempt) error) error {
+	if attempt.ID == uuid.Nil {
+		attempt.ID = uuid.New()
+	}
+	answers, err := json.Marshal(attempt.Answers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attempt answers: %w", err)
+	}
+	questions, err := json.Marshal(attempt.Questions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attempt question results: %w", err)
+	}
+	var served []byte
+	if attempt.Served != nil {
+		if served, err = json.Marshal(attempt.Served); err != nil {
+			return fmt.Errorf("failed to marshal served questions: %w", err)
+		}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	lockQuery := `SELECT pg_advisory_xact_lock(hashtextextended($1::uuid::text || $2::uuid::text, 0))`
+	if _, err := tx.Exec(ctx, lockQuery, attempt.UserID, attempt.ContentID); err != nil {
+		return fmt.Errorf("failed to lock quiz attempts: %w", err)
+	}
+	previous, err := queryAttempts(ctx, tx, userAttemptsQuery, attempt.ContentID, attempt.UserID)
+	if err != nil {
+		return err
+	}
+	if err := allow(previous); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO quiz_attempts (id, user_id, lesson_id, content_id, answers, question_results, score, min_score, status,
+		                           late, served_questions, started_at, deadline, submitted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	_, err = tx.Exec(ctx, query, attempt.ID, attempt.UserID, attempt.LessonID, attempt.ContentID, answers, questions,
+		attempt.Score, attempt.MinScore, attempt.Status, attempt.Late, served, attempt.StartedAt, attempt.Deadline, attempt.SubmittedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return app_errors.ErrQuizAttemptInProgress
+		}
+		return fmt.Errorf("failed to insert quiz attempt: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// OpenAttempt returns the learner's started but not yet submitted attempt at a quiz block
+func (r *QuizAttemptPostgres) OpenAttempt(ctx context.Context, contentID, userID uuid.UUID) (*models.QuizAttempt, error) {
+	query := `SELECT ` + quizAttemptColumns + `
+		  FROM quiz_attempts a
+		  JOIN users u ON u.id = a.user_id
+		 WHERE a.content_id = $1 AND a.user_id = $2 AND a.status = $3`
+	attempt, err := scanQuizAttempt(r.db.QueryRow(ctx, query, contentID, userID, models.AttemptStatusInProgress))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app_errors.ErrQuizNotStarted
+		}
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// FinishAttempt stores the grade of an open attempt; it fails if the attempt was already submitted
+func (r *QuizAttemptPostgres) FinishAttempt(ctx context.Context, attempt *models.QuizAttempt) error {
+	answers, err := json.Marshal(attempt.Answers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attempt answers: %w", err)
+	}
+	questions, err := json.Marshal(attempt.Questions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attempt question results: %w", err)
+	}
+	query := `
+		UPDATE quiz_attempts
+		   SET answers          = $2,
+		       question_results = $3,
+		       score            = $4,
+		       min_score        = $5,
+		       status           = $6,
+		       late             = $7,
+		       submitted_at     = $8
+		 WHERE id = $1 AND status = $9
+	`
+	cmd, err := r.db.Exec(ctx, query, attempt.ID, answers, questions, attempt.Score, attempt.MinScore,
+		attempt.Status, attempt.Late, attempt.SubmittedAt, models.AttemptStatusInProgress)
+	if err != nil {
+		return fmt.Errorf("failed to finish quiz attempt: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrQuizNotStarted
+	}
+	return nil
+}
+
+const userAttemptsQuery = `SELECT ` + quizAttemptColumns + `
+	  FROM quiz_attempts a
+	  JOIN users u ON u.id = a.user_id
+	 WHERE a.content_id = $1 AND a.user_id = $2
+	 ORDER BY a.started_at`
+
+// UserAttempts returns the learner's attempts at a quiz block, oldest first
+func (r *QuizAttemptPostgres) UserAttempts(ctx context.Context, contentID, userID uuid.UUID) ([]models.QuizAttempt, error) {
+	return queryAttempts(ctx, r.db, userAttemptsQuery, contentID, userID)
+}
+
+// ContentAttempts lists attempts of all learners at a quiz block, newest first; userID narrows it to one learner
+func (r *QuizAttemptPostgres) ContentAttempts(ctx context.Context, contentID, userID uuid.UUID, limit, offset int) ([]models.QuizAttempt, error) {
+	query := `SELECT ` + quizAttemptColumns + `
+		  FROM quiz_attempts a
+		  JOIN users u ON u.id = a.user_id
+		 WHERE a.content_id = $1 AND ($2 = '00000000-0000-0000-0000-000000000000'::uuid OR a.user_id = $2)
+		 ORDER BY a.started_at DESC
+		 LIMIT $3 OFFSET $4`
+	return queryAttempts(ctx, r.db, query, contentID, userID, limit, offset)
+}
+
+// SubmittedAttempts returns the finished attempts of all learners at a quiz block, oldest first
+func (r *QuizAttemptPostgres) SubmittedAttempts(ctx context.Context, contentID uuid.UUID) ([]models.QuizAttempt, error) {
+	query := `SELECT ` + quizAttemptColumns + `
+		  FROM quiz_attempts a
+		  JOIN users u ON u.id = a.user_id
+		 WHERE a.content_id = $1 AND a.status <> $2
+		 ORDER BY a.started_at`
+	return queryAttempts(ctx, r.db, query, contentID, models.AttemptStatusInProgress)
+}
+
+func queryAttempts(ctx context.Context, q pgxQuerier, query string, args ...any) ([]models.QuizAttempt, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quiz attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := make([]models.QuizAttempt, 0)
+	for rows.Next() {
+		attempt, err := scanQuizAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, *attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"SkillForge/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StatsPostgres struct {
+	db *pgxpool.Pool
+}
+
+func NewStatsPostgres(db *pgxpool.Pool) *StatsPostgres {
+	return &StatsPostgres{db: db}
+}
+
+func (r *StatsPostgres) PlatformStats(ctx context.Context) (models.PlatformStats, error) {
+	query := `
+		SELECT
+		    (SELECT COUNT(*) FROM users),
+		    (SELECT COUNT(*) FROM users WHERE blocked),
+		    (SELECT COUNT(DISTINCT ur.user_id) FROM user_roles ur JOIN roles r ON ur.role_id = r.id WHERE r.name = $1),
+		    (SELECT COUNT(DISTINCT ur.user_id) FROM user_roles ur JOIN roles r ON ur.role_id = r.id WHERE r.name = $2),
+		    (SELECT COUNT(DISTINCT ur.user_id) FROM user_roles ur JOIN roles r ON ur.role_id = r.id WHERE r.name = $3),
+		    (SELECT COUNT(*) FROM courses),
+		    (SELECT COUNT(*) FROM courses WHERE status = $4),
+		    (SELECT COUNT(*) FROM courses WHERE status = $5),
+		    (SELECT COUNT(*) FROM modules),
+		    (SELECT COUNT(*) FROM lessons),
+		    (SELECT COUNT(*) FROM course_subscriptions),
+		    (SELECT COUNT(*) FROM course_ratings),
+		    (SELECT COUNT(*) FROM lesson_progress WHERE status = $6),
+		    (SELECT COUNT(*) FROM refresh_tokens WHERE rotated_at IS NULL AND expires_at > NOW())
+	`
+	var s models.PlatformStats
+	err := r.db.QueryRow(ctx, query,
+		models.ClientRole, models.AuthorRole, models.AdminRole,
+		models.StatusPublic, models.StatusHidden, models.LessonStatusPassed,
+	).Scan(
+		&s.Users, &s.BlockedUsers, &s.Clients, &s.Authors, &s.Admins,
+		&s.Courses, &s.PublicCourses, &s.HiddenCourses,
+		&s.Modules, &s.Lessons, &s.Subscriptions, &s.Ratings,
+		&s.PassedLessons, &s.ActiveSessions,
+	)
+	if err != nil {
+		return models.PlatformStats{}, fmt.Errorf("failed to collect platform stats: %w", err)
+	}
+	return s, nil
+}
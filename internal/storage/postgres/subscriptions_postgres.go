@@ -59,3 +59,12 @@ func (r *SubscriptionPostgres) GetSubscribedCourses(ctx context.Context, userID
 	}
 	return courses, nil
 }
+
+func (r *SubscriptionPostgres) IsSubscribed(ctx context.Context, courseID, userID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM course_subscriptions WHERE course_id = $1 AND user_id = $2)`
+	var subscribed bool
+	if err := r.db.QueryRow(ctx, query, courseID, userID).Scan(&subscribed); err != nil {
+		return false, fmt.Errorf("failed to check subscription: %w", err)
+	}
+	return subscribed, nil
+}
@@ -30,7 +30,11 @@ func (r *TokensPostgres) hashToken(token *jwt.Token) (string, error) {
 	return base64TokenHash, nil
 }
 
-func (r *TokensPostgres) Create(ctx context.Context, userID uuid.UUID, token *jwt.Token) (*models.RefreshToken, error) {
+func (r *TokensPostgres) Create(ctx context.Context, session models.RefreshToken, token *jwt.Token) (*models.RefreshToken, error) {
+	return r.create(ctx, r.db, session, token)
+}
+
+func (r *TokensPostgres) create(ctx context.Context, q pgxQuerier, session models.RefreshToken, token *jwt.Token) (*models.RefreshToken, error) {
 	hashedToken, err := r.hashToken(token)
 	if err != nil {
 		return nil, err
@@ -40,21 +44,26 @@ func (r *TokensPostgres) Create(ctx context.Context, userID uuid.UUID, token *jw
 		return nil, err
 	}
 	expiresAtFormat := expiresAt.Format(time.RFC3339)
+	session.ID = uuid.New()
+	if session.FamilyID == uuid.Nil {
+		session.FamilyID = session.ID
+	}
 	query := `
-		INSERT INTO refresh_tokens (user_id, hashed_token, expires_at)
-		VALUES ($1, $2, $3)
-		RETURNING created_at, expires_at
+		INSERT INTO refresh_tokens (id, family_id, user_id, hashed_token, expires_at, device_name, user_agent, ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, last_used_at, expires_at
 	`
-	refreshToken := &models.RefreshToken{
-		UserID:      userID,
-		HashedToken: hashedToken,
-	}
-	err = r.db.QueryRow(ctx, query, userID, hashedToken, expiresAtFormat).Scan(&refreshToken.CreatedAt, &refreshToken.ExpiresAt)
+	session.HashedToken = hashedToken
+	session.RotatedAt = nil
+	err = q.QueryRow(ctx, query,
+		session.ID, session.FamilyID, session.UserID, hashedToken, expiresAtFormat,
+		session.DeviceName, session.UserAgent, session.IP,
+	).Scan(&session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
 
-	return refreshToken, nil
+	return &session, nil
 }
 
 func (r *TokensPostgres) ByPrimaryKey(ctx context.Context, userID uuid.UUID, token *jwt.Token) (*models.RefreshToken, error) {
@@ -62,9 +71,18 @@ func (r *TokensPostgres) ByPrimaryKey(ctx context.Context, userID uuid.UUID, tok
 	if err != nil {
 		return nil, err
 	}
-	query := `SELECT * FROM refresh_tokens WHERE user_id = $1 AND hashed_token = $2`
+	query := `
+		SELECT id, family_id, user_id, hashed_token, device_name, user_agent, ip,
+		       created_at, last_used_at, expires_at, rotated_at
+		FROM refresh_tokens
+		WHERE user_id = $1 AND hashed_token = $2
+	`
 	refreshToken := models.RefreshToken{}
-	err = r.db.QueryRow(ctx, query, userID, hashedToken).Scan(&refreshToken.UserID, &refreshToken.HashedToken, &refreshToken.CreatedAt, &refreshToken.ExpiresAt)
+	err = r.db.QueryRow(ctx, query, userID, hashedToken).Scan(
+		&refreshToken.ID, &refreshToken.FamilyID, &refreshToken.UserID, &refreshToken.HashedToken,
+		&refreshToken.DeviceName, &refreshToken.UserAgent, &refreshToken.IP,
+		&refreshToken.CreatedAt, &refreshToken.LastUsedAt, &refreshToken.ExpiresAt, &refreshToken.RotatedAt,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, app_errors.ErrTokenNotFound
@@ -74,6 +92,133 @@ func (r *TokensPostgres) ByPrimaryKey(ctx context.Context, userID uuid.UUID, tok
 	return &refreshToken, nil
 }
 
+// Rotate marks the presented token as used and stores its successor in the same
+// family. Losing the race to mark the token means it was already rotated, which
+// is treated as reuse: the whole family is revoked and ErrTokenReused returned.
+func (r *TokensPostgres) Rotate(ctx context.Context, current models.RefreshToken, token *jwt.Token, info models.SessionInfo) (*models.RefreshToken, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	markQuery := `
+		UPDATE refresh_tokens
+		   SET rotated_at = NOW()
+		 WHERE id = $1 AND rotated_at IS NULL
+	`
+	cmd, err := tx.Exec(ctx, markQuery, current.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.RowsAffected() == 0 {
+		_ = tx.Rollback(ctx)
+		if err := r.RevokeFamily(ctx, current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, app_errors.ErrTokenReused
+	}
+
+	next := models.RefreshToken{
+		FamilyID:   current.FamilyID,
+		UserID:     current.UserID,
+		DeviceName: current.DeviceName,
+		UserAgent:  info.UserAgent,
+		IP:         info.IP,
+	}
+	created, err := r.create(ctx, tx, next, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *TokensPostgres) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM refresh_tokens WHERE family_id = $1`, familyID)
+	return err
+}
+
+func (r *TokensPostgres) UserSessions(ctx context.Context, userID uuid.UUID) ([]models.RefreshToken, error) {
+	query := `
+		SELECT t.id, t.family_id, t.user_id, t.hashed_token, t.device_name, t.user_agent, t.ip,
+		       (SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = t.family_id),
+		       t.last_used_at, t.expires_at, t.rotated_at
+		FROM refresh_tokens t
+		WHERE t.user_id = $1 AND t.rotated_at IS NULL AND t.expires_at > NOW()
+		ORDER BY t.last_used_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []models.RefreshToken
+	for rows.Next() {
+		var s models.RefreshToken
+		if err := rows.Scan(
+			&s.ID, &s.FamilyID, &s.UserID, &s.HashedToken,
+			&s.DeviceName, &s.UserAgent, &s.IP,
+			&s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt, &s.RotatedAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// TouchSession checks that the session is still active on every request, but writes last_used_at
+// at most once a minute so read-only calls do not each cost an UPDATE
+func (r *TokensPostgres) TouchSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	query := `
+		WITH session AS (
+			SELECT id, last_used_at
+			  FROM refresh_tokens
+			 WHERE family_id = $1 AND user_id = $2 AND rotated_at IS NULL AND expires_at > NOW()
+		), touched AS (
+			UPDATE refresh_tokens t
+			   SET last_used_at = NOW()
+			  FROM session s
+			 WHERE t.id = s.id AND s.last_used_at < NOW() - INTERVAL '1 minute'
+		)
+		SELECT EXISTS (SELECT 1 FROM session)
+	`
+	var active bool
+	if err := r.db.QueryRow(ctx, query, sessionID, userID).Scan(&active); err != nil {
+		return err
+	}
+	if !active {
+		return app_errors.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *TokensPostgres) DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	query := `DELETE FROM refresh_tokens WHERE family_id = $1 AND user_id = $2`
+	cmd, err := r.db.Exec(ctx, query, sessionID, userID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *TokensPostgres) DeleteExpiredTokens(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM refresh_tokens WHERE user_id = $1 AND expires_at <= NOW()`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
+
 func (r *TokensPostgres) DeleteUserTokens(ctx context.Context, userID uuid.UUID) error {
 	query := `DELETE FROM refresh_tokens WHERE user_id = $1`
 	_, err := r.db.Exec(ctx, query, userID)
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"strings"
 )
 
 type UserPostgres struct {
@@ -22,7 +23,8 @@ func NewUserPostgres(db *pgxpool.Pool) *UserPostgres {
 
 func (r *UserPostgres) UserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT u.id, u.username, u.password, u.email, array_agg(r.name)
+		SELECT u.id, u.username, u.password, u.email, u.email_verified, u.blocked,
+		       array_remove(array_agg(r.name), NULL)
 		FROM users u
 		LEFT JOIN user_roles ur ON u.id = ur.user_id
 		LEFT JOIN roles r ON ur.role_id = r.id
@@ -34,7 +36,7 @@ func (r *UserPostgres) UserByID(ctx context.Context, id uuid.UUID) (*models.User
 	var user models.User
 	var roles []string
 
-	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &roles)
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.EmailVerified, &user.Blocked, &roles)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, app_errors.ErrUserNotFound
@@ -48,7 +50,8 @@ func (r *UserPostgres) UserByID(ctx context.Context, id uuid.UUID) (*models.User
 
 func (r *UserPostgres) UserByName(ctx context.Context, name string) (*models.User, error) {
 	query := `
-		SELECT u.id, u.username, u.password, u.email, array_agg(r.name)
+		SELECT u.id, u.username, u.password, u.email, u.email_verified, u.blocked,
+		       array_remove(array_agg(r.name), NULL)
 		FROM users u
 		LEFT JOIN user_roles ur ON u.id = ur.user_id
 		LEFT JOIN roles r ON ur.role_id = r.id
@@ -60,7 +63,7 @@ func (r *UserPostgres) UserByName(ctx context.Context, name string) (*models.Use
 	var user models.User
 	var roles []string
 
-	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &roles)
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.EmailVerified, &user.Blocked, &roles)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, app_errors.ErrUserNotFound
@@ -72,6 +75,55 @@ func (r *UserPostgres) UserByName(ctx context.Context, name string) (*models.Use
 	return &user, nil
 }
 
+func (r *UserPostgres) UserByEmail(ctx context.Context, email string) (*models.User, error) {
+	query := `
+		SELECT u.id, u.username, u.password, u.email, u.email_verified, u.blocked,
+		       array_remove(array_agg(r.name), NULL)
+		FROM users u
+		LEFT JOIN user_roles ur ON u.id = ur.user_id
+		LEFT JOIN roles r ON ur.role_id = r.id
+		WHERE lower(u.email) = lower($1)
+		GROUP BY u.id
+	`
+
+	row := r.db.QueryRow(ctx, query, email)
+	var user models.User
+	var roles []string
+
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.EmailVerified, &user.Blocked, &roles)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app_errors.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	user.Roles = roles
+	return &user, nil
+}
+
+func (r *UserPostgres) UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error {
+	cmd, err := r.db.Exec(ctx, `UPDATE users SET password = $2 WHERE id = $1`, userID, hashedPassword)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *UserPostgres) SetEmailVerified(ctx context.Context, userID uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `UPDATE users SET email_verified = true WHERE id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrUserNotFound
+	}
+	return nil
+}
+
 func (r *UserPostgres) CreateUser(ctx context.Context, user models.User) (*models.User, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
@@ -113,3 +165,90 @@ func (r *UserPostgres) CreateUser(ctx context.Context, user models.User) (*model
 
 	return &user, nil
 }
+
+// likeEscaper makes % and _ in user input match literally in LIKE patterns, backslash is the default escape
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *UserPostgres) ListUsers(ctx context.Context, search, role string, limit, offset int) ([]models.User, int, error) {
+	search = likeEscaper.Replace(search)
+	filter := `
+		WHERE ($1 = '' OR u.username ILIKE '%' || $1 || '%' OR u.email ILIKE '%' || $1 || '%')
+		  AND ($2 = '' OR EXISTS (
+		      SELECT 1 FROM user_roles fur JOIN roles fr ON fur.role_id = fr.id
+		       WHERE fur.user_id = u.id AND fr.name = $2))
+	`
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM users u `+filter, search, role).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := `
+		SELECT u.id, u.username, u.email, u.email_verified, u.blocked,
+		       array_remove(array_agg(r.name ORDER BY r.name), NULL)
+		FROM users u
+		LEFT JOIN user_roles ur ON u.id = ur.user_id
+		LEFT JOIN roles r ON ur.role_id = r.id
+	` + filter + `
+		GROUP BY u.id
+		ORDER BY u.username
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.Query(ctx, query, search, role, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.EmailVerified, &user.Blocked, &user.Roles); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (r *UserPostgres) AddRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	var roleID int
+	if err := r.db.QueryRow(ctx, `SELECT id FROM roles WHERE name = $1`, roleName).Scan(&roleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return app_errors.ErrRoleNotFound
+		}
+		return err
+	}
+	query := `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := r.db.Exec(ctx, query, userID, roleID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return app_errors.ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *UserPostgres) RemoveRole(ctx context.Context, userID uuid.UUID, roleName string) error {
+	query := `
+		DELETE FROM user_roles
+		 WHERE user_id = $1
+		   AND role_id = (SELECT id FROM roles WHERE name = $2)
+	`
+	_, err := r.db.Exec(ctx, query, userID, roleName)
+	return err
+}
+
+func (r *UserPostgres) SetBlocked(ctx context.Context, userID uuid.UUID, blocked bool) error {
+	cmd, err := r.db.Exec(ctx, `UPDATE users SET blocked = $2 WHERE id = $1`, userID, blocked)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return app_errors.ErrUserNotFound
+	}
+	return nil
+}
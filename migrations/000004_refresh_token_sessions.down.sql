@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS refresh_tokens_user_id_idx;
+
+ALTER TABLE refresh_tokens
+    DROP CONSTRAINT IF EXISTS refresh_tokens_id_key;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS ip,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS device_name,
+    DROP COLUMN IF EXISTS id;
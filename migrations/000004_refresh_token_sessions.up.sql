@@ -0,0 +1,12 @@
+-- Каждый refresh токен становится отдельной сессией устройства
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS id           uuid                     NOT NULL DEFAULT gen_random_uuid(),
+    ADD COLUMN IF NOT EXISTS device_name  text                     NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS user_agent   text                     NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS ip           text                     NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_used_at timestamp with time zone NOT NULL DEFAULT now();
+
+ALTER TABLE refresh_tokens
+    ADD CONSTRAINT refresh_tokens_id_key UNIQUE (id);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens (user_id);
@@ -0,0 +1,8 @@
+DELETE FROM refresh_tokens
+WHERE rotated_at IS NOT NULL;
+
+DROP INDEX IF EXISTS refresh_tokens_family_id_idx;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS rotated_at,
+    DROP COLUMN IF EXISTS family_id;
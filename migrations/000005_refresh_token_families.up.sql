@@ -0,0 +1,13 @@
+-- Семейства refresh токенов: ротация создает новую запись, старая помечается rotated_at
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS family_id  uuid,
+    ADD COLUMN IF NOT EXISTS rotated_at timestamp with time zone;
+
+UPDATE refresh_tokens
+SET family_id = id
+WHERE family_id IS NULL;
+
+ALTER TABLE refresh_tokens
+    ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
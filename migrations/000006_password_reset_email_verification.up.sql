@@ -0,0 +1,23 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_verified boolean NOT NULL DEFAULT false;
+
+-- Аккаунты, созданные до подтверждения почты, считаются подтверждёнными, иначе они не смогут войти
+UPDATE users SET email_verified = true;
+
+-- Одноразовые токены для сброса пароля и подтверждения почты
+create table if not exists user_action_tokens
+(
+    id         uuid                                   not null
+        primary key,
+    user_id    uuid                                   not null
+        references users
+            on delete cascade,
+    purpose    text                                   not null
+        constraint user_action_tokens_purpose_check
+            check (purpose = ANY (ARRAY ['password_reset'::text, 'email_verify'::text])),
+    created_at timestamp with time zone default now() not null,
+    expires_at timestamp with time zone               not null,
+    used_at    timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS user_action_tokens_user_id_idx ON user_action_tokens (user_id, purpose);
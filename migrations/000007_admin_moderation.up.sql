@@ -0,0 +1,8 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS blocked boolean NOT NULL DEFAULT false;
+
+INSERT INTO roles (name)
+VALUES ('client'),
+       ('author'),
+       ('admin')
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,25 @@
+-- Заявки пользователей на получение роли автора
+create table if not exists author_applications
+(
+    id               uuid                                   not null
+        primary key,
+    user_id          uuid                                   not null
+        references users
+            on delete cascade,
+    motivation       text                                   not null,
+    status           text                     default 'pending' not null
+        constraint author_applications_status_check
+            check (status = ANY (ARRAY ['pending'::text, 'approved'::text, 'rejected'::text])),
+    rejection_reason text,
+    reviewed_by      uuid
+        references users
+            on delete set null,
+    created_at       timestamp with time zone default now() not null,
+    reviewed_at      timestamp with time zone
+);
+
+-- у пользователя может быть только одна нерассмотренная заявка
+CREATE UNIQUE INDEX IF NOT EXISTS author_applications_pending_idx
+    ON author_applications (user_id) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS author_applications_status_idx ON author_applications (status, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE lessons
+    DROP COLUMN IF EXISTS free_preview;
@@ -0,0 +1,3 @@
+-- Уроки, доступные для чтения без подписки на курс
+ALTER TABLE lessons
+    ADD COLUMN IF NOT EXISTS free_preview boolean NOT NULL DEFAULT false;
@@ -0,0 +1,6 @@
+ALTER TABLE lesson_progress
+    DROP COLUMN IF EXISTS question_results,
+    DROP COLUMN IF EXISTS min_score;
+
+ALTER TABLE courses
+    DROP COLUMN IF EXISTS default_min_score;
@@ -0,0 +1,9 @@
+-- Проходной балл по умолчанию для тестов курса (если в тесте не задан minScore)
+ALTER TABLE courses
+    ADD COLUMN IF NOT EXISTS default_min_score double precision NOT NULL DEFAULT 100
+        CONSTRAINT courses_default_min_score_check CHECK (default_min_score >= 0 AND default_min_score <= 100);
+
+-- Порог и разбор по вопросам на момент прохождения
+ALTER TABLE lesson_progress
+    ADD COLUMN IF NOT EXISTS min_score        double precision NOT NULL DEFAULT 100,
+    ADD COLUMN IF NOT EXISTS question_results jsonb            NOT NULL DEFAULT '[]'::jsonb;
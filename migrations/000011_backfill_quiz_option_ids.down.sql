@@ -0,0 +1,2 @@
+-- Проставленные ID остаются в данных: они совместимы с прежней позиционной проверкой
+SELECT 1;
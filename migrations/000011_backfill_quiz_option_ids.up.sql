@@ -0,0 +1,35 @@
+-- Проставляем постоянные ID вариантам ответов (и вопросам) в существующих тестах.
+-- Пустые ID заполняются позиционными значениями option_N / question_N, которые раньше
+-- вычислялись из индекса при проверке, поэтому уже выданные ответы остаются верными.
+UPDATE contents c
+SET quiz_json = jsonb_set(c.quiz_json, '{questions}', (
+    SELECT COALESCE(jsonb_agg(
+        CASE
+            WHEN jsonb_typeof(q.question->'options') = 'array' THEN
+                jsonb_set(q.filled, '{options}', (
+                    SELECT COALESCE(jsonb_agg(
+                        CASE
+                            WHEN COALESCE(o.option->>'id', '') = ''
+                                THEN o.option || jsonb_build_object('id', 'option_' || (o.idx - 1))
+                            ELSE o.option
+                        END
+                        ORDER BY o.idx), '[]'::jsonb)
+                    FROM jsonb_array_elements(q.question->'options') WITH ORDINALITY AS o(option, idx)
+                ))
+            ELSE q.filled
+        END
+        ORDER BY q.idx), '[]'::jsonb)
+    FROM (
+        SELECT question,
+               idx,
+               CASE
+                   WHEN COALESCE(question->>'id', '') = ''
+                       THEN question || jsonb_build_object('id', 'question_' || (idx - 1))
+                   ELSE question
+               END AS filled
+        FROM jsonb_array_elements(c.quiz_json->'questions') WITH ORDINALITY AS t(question, idx)
+    ) AS q
+))
+WHERE c.type = 'quiz'
+  AND c.quiz_json IS NOT NULL
+  AND jsonb_typeof(c.quiz_json->'questions') = 'array';
@@ -0,0 +1,4 @@
+ALTER TABLE courses
+    DROP COLUMN IF EXISTS score_policy;
+
+DROP TABLE IF EXISTS quiz_attempts;
@@ -0,0 +1,33 @@
+-- Каждая отправка теста хранится отдельно, lesson_progress содержит итог по политике курса
+create table if not exists quiz_attempts
+(
+    id               uuid                     default gen_random_uuid() not null
+        primary key,
+    user_id          uuid                                               not null
+        references users
+            on delete cascade,
+    lesson_id        uuid                                               not null
+        references lessons
+            on delete cascade,
+    answers          jsonb                    default '[]'::jsonb       not null,
+    question_results jsonb                    default '[]'::jsonb       not null,
+    score            double precision         default 0                 not null,
+    min_score        double precision         default 100               not null,
+    status           text                                               not null
+        constraint quiz_attempts_status_check
+            check (status = ANY (ARRAY ['passed'::text, 'failed'::text])),
+    submitted_at     timestamp with time zone default now()             not null
+);
+
+CREATE INDEX IF NOT EXISTS quiz_attempts_user_lesson_idx ON quiz_attempts (user_id, lesson_id, submitted_at);
+CREATE INDEX IF NOT EXISTS quiz_attempts_lesson_idx ON quiz_attempts (lesson_id, submitted_at);
+
+-- Политика итоговой оценки: лучшая, последняя или средняя попытка
+ALTER TABLE courses
+    ADD COLUMN IF NOT EXISTS score_policy text NOT NULL DEFAULT 'best'
+        CONSTRAINT courses_score_policy_check CHECK (score_policy = ANY (ARRAY ['best'::text, 'latest'::text, 'average'::text]));
+
+-- Уже сохранённые результаты становятся первой попыткой (ответы не сохранялись)
+INSERT INTO quiz_attempts (user_id, lesson_id, question_results, score, min_score, status, submitted_at)
+SELECT user_id, lesson_id, question_results, score, min_score, status, updated_at
+FROM lesson_progress;
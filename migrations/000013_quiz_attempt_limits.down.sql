@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS quiz_attempts_open_idx;
+
+DELETE FROM quiz_attempts WHERE status = 'in_progress';
+
+ALTER TABLE quiz_attempts
+    DROP CONSTRAINT IF EXISTS quiz_attempts_status_check,
+    ADD CONSTRAINT quiz_attempts_status_check
+        CHECK (status = ANY (ARRAY ['passed'::text, 'failed'::text])),
+    ALTER COLUMN submitted_at SET DEFAULT now(),
+    ALTER COLUMN submitted_at SET NOT NULL,
+    DROP COLUMN IF EXISTS late,
+    DROP COLUMN IF EXISTS deadline,
+    DROP COLUMN IF EXISTS started_at;
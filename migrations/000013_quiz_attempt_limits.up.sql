@@ -0,0 +1,20 @@
+-- Попытки с ограничением по времени: начинаются до отправки и имеют срок сдачи
+ALTER TABLE quiz_attempts
+    ADD COLUMN IF NOT EXISTS started_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS deadline   timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS late       boolean NOT NULL DEFAULT false,
+    ALTER COLUMN submitted_at DROP NOT NULL,
+    ALTER COLUMN submitted_at DROP DEFAULT;
+
+UPDATE quiz_attempts SET started_at = submitted_at WHERE started_at IS NULL;
+
+ALTER TABLE quiz_attempts
+    ALTER COLUMN started_at SET DEFAULT now(),
+    ALTER COLUMN started_at SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS quiz_attempts_status_check,
+    ADD CONSTRAINT quiz_attempts_status_check
+        CHECK (status = ANY (ARRAY ['in_progress'::text, 'passed'::text, 'failed'::text]));
+
+-- у пользователя может быть только одна незавершённая попытка по уроку
+CREATE UNIQUE INDEX IF NOT EXISTS quiz_attempts_open_idx
+    ON quiz_attempts (user_id, lesson_id) WHERE status = 'in_progress';
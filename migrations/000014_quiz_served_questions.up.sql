@@ -0,0 +1,3 @@
+-- Вопросы, выданные в попытке (выборка из пула и порядок вариантов); NULL — все вопросы теста
+ALTER TABLE quiz_attempts
+    ADD COLUMN IF NOT EXISTS served_questions jsonb;
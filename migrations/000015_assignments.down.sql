@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS assignment_submissions;
+
+DELETE FROM contents WHERE type = 'assignment';
+
+ALTER TABLE contents
+    DROP CONSTRAINT IF EXISTS contents_type_check,
+    ADD CONSTRAINT contents_type_check
+        CHECK (type = ANY (ARRAY ['text'::text, 'image'::text, 'video'::text, 'quiz'::text])),
+    DROP COLUMN IF EXISTS assignment_json;
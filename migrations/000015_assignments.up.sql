@@ -0,0 +1,48 @@
+-- Задания с ручной проверкой: описание и критерии оценивания хранятся в assignment_json
+ALTER TABLE contents
+    ADD COLUMN IF NOT EXISTS assignment_json jsonb,
+    DROP CONSTRAINT IF EXISTS contents_type_check,
+    ADD CONSTRAINT contents_type_check
+        CHECK (type = ANY (ARRAY ['text'::text, 'image'::text, 'video'::text, 'quiz'::text, 'assignment'::text]));
+
+-- Ответы учеников на задания; файлы лежат в хранилище уроков, здесь только их ключи
+create table if not exists assignment_submissions
+(
+    id            uuid                     default gen_random_uuid() not null
+        primary key,
+    content_id    uuid                                               not null
+        references contents
+            on delete cascade,
+    lesson_id     uuid                                               not null
+        references lessons
+            on delete cascade,
+    user_id       uuid                                               not null
+        references users
+            on delete cascade,
+    text          text                     default ''                not null,
+    files         jsonb                    default '[]'::jsonb       not null,
+    status        text                                               not null
+        constraint assignment_submissions_status_check
+            check (status = ANY (ARRAY ['submitted'::text, 'graded'::text])),
+    rubric_scores jsonb                    default '[]'::jsonb       not null,
+    points        double precision         default 0                 not null,
+    max_points    double precision         default 0                 not null,
+    score         double precision         default 0                 not null,
+    min_score     double precision         default 100               not null,
+    result        text
+        constraint assignment_submissions_result_check
+            check (result = ANY (ARRAY ['passed'::text, 'failed'::text])),
+    feedback      text                     default ''                not null,
+    graded_by     uuid
+        references users
+            on delete set null,
+    submitted_at  timestamp with time zone default now()             not null,
+    graded_at     timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS assignment_submissions_user_idx ON assignment_submissions (content_id, user_id, submitted_at);
+CREATE INDEX IF NOT EXISTS assignment_submissions_queue_idx ON assignment_submissions (status, submitted_at);
+
+-- пока ответ не проверен, новый отправить нельзя
+CREATE UNIQUE INDEX IF NOT EXISTS assignment_submissions_pending_idx
+    ON assignment_submissions (content_id, user_id) WHERE status = 'submitted';
@@ -0,0 +1,25 @@
+UPDATE lesson_progress SET status = 'failed' WHERE status = 'in_progress';
+
+ALTER TABLE lesson_progress
+    DROP CONSTRAINT IF EXISTS lesson_progress_status_check,
+    ADD CONSTRAINT lesson_progress_status_check
+        CHECK (status = ANY (ARRAY ['passed'::text, 'failed'::text])),
+    DROP COLUMN IF EXISTS blocks,
+    ADD COLUMN IF NOT EXISTS question_results jsonb NOT NULL DEFAULT '[]'::jsonb;
+
+DROP INDEX IF EXISTS quiz_attempts_user_content_idx;
+DROP INDEX IF EXISTS quiz_attempts_open_idx;
+
+-- в уроке снова допускается только одна незавершённая попытка
+DELETE FROM quiz_attempts a
+WHERE a.status = 'in_progress'
+  AND EXISTS (SELECT 1
+              FROM quiz_attempts b
+              WHERE b.user_id = a.user_id AND b.lesson_id = a.lesson_id
+                AND b.status = 'in_progress' AND b.started_at > a.started_at);
+
+CREATE UNIQUE INDEX IF NOT EXISTS quiz_attempts_open_idx
+    ON quiz_attempts (user_id, lesson_id) WHERE status = 'in_progress';
+
+ALTER TABLE quiz_attempts
+    DROP COLUMN IF EXISTS content_id;
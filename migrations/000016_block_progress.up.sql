@@ -0,0 +1,48 @@
+-- Попытки относятся к конкретному блоку теста, а не к уроку: в уроке может быть несколько тестов
+ALTER TABLE quiz_attempts
+    ADD COLUMN IF NOT EXISTS content_id uuid REFERENCES contents ON DELETE CASCADE;
+
+-- старые попытки принадлежат первому тесту урока
+UPDATE quiz_attempts a
+SET content_id = (SELECT c.id
+                  FROM contents c
+                  WHERE c.lesson_id = a.lesson_id AND c.type = 'quiz'
+                  ORDER BY c.order_num
+                  LIMIT 1)
+WHERE a.content_id IS NULL;
+
+-- попытки удалённых тестов не к чему привязать
+DELETE FROM quiz_attempts WHERE content_id IS NULL;
+
+ALTER TABLE quiz_attempts
+    ALTER COLUMN content_id SET NOT NULL;
+
+DROP INDEX IF EXISTS quiz_attempts_open_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS quiz_attempts_open_idx
+    ON quiz_attempts (user_id, content_id) WHERE status = 'in_progress';
+CREATE INDEX IF NOT EXISTS quiz_attempts_user_content_idx ON quiz_attempts (user_id, content_id, started_at);
+
+-- Итог урока складывается из результатов всех оцениваемых блоков
+ALTER TABLE lesson_progress
+    ADD COLUMN IF NOT EXISTS blocks jsonb NOT NULL DEFAULT '[]'::jsonb,
+    DROP CONSTRAINT IF EXISTS lesson_progress_status_check,
+    ADD CONSTRAINT lesson_progress_status_check
+        CHECK (status = ANY (ARRAY ['in_progress'::text, 'passed'::text, 'failed'::text]));
+
+UPDATE lesson_progress p
+SET blocks = jsonb_build_array(jsonb_build_object(
+        'content_id', c.id,
+        'type', 'quiz',
+        'status', p.status,
+        'score', p.score,
+        'min_score', p.min_score))
+FROM contents c
+WHERE c.id = (SELECT q.id
+              FROM contents q
+              WHERE q.lesson_id = p.lesson_id AND q.type = 'quiz'
+              ORDER BY q.order_num
+              LIMIT 1);
+
+-- разбор по вопросам хранится в попытках
+ALTER TABLE lesson_progress
+    DROP COLUMN IF EXISTS question_results;
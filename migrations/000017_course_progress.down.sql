@@ -0,0 +1,6 @@
+DELETE FROM lesson_progress WHERE status = 'completed';
+
+ALTER TABLE lesson_progress
+    DROP CONSTRAINT IF EXISTS lesson_progress_status_check,
+    ADD CONSTRAINT lesson_progress_status_check
+        CHECK (status = ANY (ARRAY ['in_progress'::text, 'passed'::text, 'failed'::text]));
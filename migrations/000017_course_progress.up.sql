@@ -0,0 +1,5 @@
+-- Уроки без тестов и заданий отмечаются пройденными вручную или при просмотре
+ALTER TABLE lesson_progress
+    DROP CONSTRAINT IF EXISTS lesson_progress_status_check,
+    ADD CONSTRAINT lesson_progress_status_check
+        CHECK (status = ANY (ARRAY ['in_progress'::text, 'passed'::text, 'failed'::text, 'completed'::text]));
@@ -0,0 +1,2 @@
+-- Без minScore блок наследует порог курса и при прежней схеме, возвращать нули не нужно
+SELECT 1;
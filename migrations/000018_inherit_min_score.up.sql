@@ -0,0 +1,13 @@
+-- Раньше minScore = 0 означал порог курса по умолчанию; теперь порог курса наследуется только при отсутствии поля,
+-- а 0 означает явный порог
+UPDATE contents
+SET quiz_json = quiz_json - 'minScore'
+WHERE quiz_json IS NOT NULL
+  AND jsonb_typeof(quiz_json -> 'minScore') = 'number'
+  AND (quiz_json ->> 'minScore')::float = 0;
+
+UPDATE contents
+SET assignment_json = assignment_json - 'minScore'
+WHERE assignment_json IS NOT NULL
+  AND jsonb_typeof(assignment_json -> 'minScore') = 'number'
+  AND (assignment_json ->> 'minScore')::float = 0;
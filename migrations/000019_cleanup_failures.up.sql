@@ -0,0 +1,19 @@
+-- Объекты удалённых курсов, которые не удалось убрать из хранилища и поиска; администратор повторяет очистку
+create table if not exists cleanup_failures
+(
+    id            uuid                                   not null
+        primary key,
+    course_id     uuid                                   not null,
+    target        text                                   not null
+        constraint cleanup_failures_target_check
+            check (target = ANY
+                   (ARRAY ['logo'::text, 'lesson_media'::text, 'search_index'::text, 'assignment_submissions'::text])),
+    object_key    text                     default ''    not null,
+    content_type  text                     default ''    not null,
+    error         text                                   not null,
+    attempts      integer                  default 1     not null,
+    created_at    timestamp with time zone default now() not null,
+    last_tried_at timestamp with time zone default now() not null
+);
+
+CREATE INDEX IF NOT EXISTS cleanup_failures_created_at_idx ON cleanup_failures (created_at);